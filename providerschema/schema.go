@@ -0,0 +1,195 @@
+package providerschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// ProviderSchemas is the index of the schemas produced by `terraform providers schema -json`
+type ProviderSchemas struct {
+	FormatVersion string
+	resources     map[string]*Block
+	dataSources   map[string]*Block
+}
+
+// Block is the schema of a resource/data source or one of its nested blocks
+type Block struct {
+	Attributes  map[string]*Attribute       `json:"attributes"`
+	BlockTypes  map[string]*NestedBlockType `json:"block_types"`
+	Description string                      `json:"description"`
+	Deprecated  bool                        `json:"deprecated"`
+}
+
+// Attribute is the schema of an attribute
+type Attribute struct {
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Optional    bool   `json:"optional"`
+	Computed    bool   `json:"computed"`
+	Sensitive   bool   `json:"sensitive"`
+	Deprecated  bool   `json:"deprecated"`
+}
+
+// NestedBlockType is the schema of a type of nested block
+type NestedBlockType struct {
+	NestingMode string `json:"nesting_mode"`
+	Block       *Block `json:"block"`
+	MinItems    int    `json:"min_items"`
+	MaxItems    int    `json:"max_items"`
+}
+
+type providerSchemasJSON struct {
+	FormatVersion   string                         `json:"format_version"`
+	ProviderSchemas map[string]*providerSchemaJSON `json:"provider_schemas"`
+}
+
+type providerSchemaJSON struct {
+	ResourceSchemas   map[string]*resourceSchemaJSON `json:"resource_schemas"`
+	DataSourceSchemas map[string]*resourceSchemaJSON `json:"data_source_schemas"`
+}
+
+type resourceSchemaJSON struct {
+	Version int    `json:"version"`
+	Block   *Block `json:"block"`
+}
+
+// Load reads the schemas from a local file produced by `terraform providers schema -json`
+func Load(path string) (*ProviderSchemas, error) {
+	// #nosec G304
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read provider schema file %s: %w", path, err)
+	}
+	schemas, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider schema file %s: %w", path, err)
+	}
+	return schemas, nil
+}
+
+// Parse indexes the resources and data sources of all providers in the given json content
+func Parse(content []byte) (*ProviderSchemas, error) {
+	var raw providerSchemasJSON
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	if raw.ProviderSchemas == nil {
+		return nil, fmt.Errorf("`provider_schemas` is not found")
+	}
+	schemas := &ProviderSchemas{
+		FormatVersion: raw.FormatVersion,
+		resources:     make(map[string]*Block),
+		dataSources:   make(map[string]*Block),
+	}
+	// iterate providers in a fixed order so that the result is stable when the same type is declared by more than one provider
+	var providers []string
+	for provider := range raw.ProviderSchemas {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	for _, provider := range providers {
+		p := raw.ProviderSchemas[provider]
+		if p == nil {
+			continue
+		}
+		index(schemas.resources, p.ResourceSchemas)
+		index(schemas.dataSources, p.DataSourceSchemas)
+	}
+	return schemas, nil
+}
+
+func index(target map[string]*Block, resourceSchemas map[string]*resourceSchemaJSON) {
+	for name, s := range resourceSchemas {
+		if _, exist := target[name]; exist || s == nil || s.Block == nil {
+			continue
+		}
+		target[name] = s.Block
+	}
+}
+
+// Resource returns the schema of the given resource type, nil if not found
+func (s *ProviderSchemas) Resource(resourceType string) *Block {
+	return s.resources[resourceType]
+}
+
+// DataSource returns the schema of the given data source type, nil if not found
+func (s *ProviderSchemas) DataSource(dataSourceType string) *Block {
+	return s.dataSources[dataSourceType]
+}
+
+// BlockSchema returns the schema of a top level `resource`/`data` block with the given type, nil if not found
+func (s *ProviderSchemas) BlockSchema(blockType string, resourceType string) *Block {
+	switch blockType {
+	case "resource":
+		return s.Resource(resourceType)
+	case "data":
+		return s.DataSource(resourceType)
+	}
+	return nil
+}
+
+// NestedBlock returns the schema of the nested block at the given path of block type names, nil if not found
+func (b *Block) NestedBlock(path ...string) *Block {
+	block := b
+	for _, name := range path {
+		if block == nil {
+			return nil
+		}
+		nb, ok := block.BlockTypes[name]
+		if !ok || nb == nil {
+			return nil
+		}
+		block = nb.Block
+	}
+	return block
+}
+
+// Attribute returns the schema of the given attribute, nil if not found
+func (b *Block) Attribute(name string) *Attribute {
+	if b == nil {
+		return nil
+	}
+	return b.Attributes[name]
+}
+
+// RequiredArgs returns the sorted names of required attributes and nested blocks with min_items > 0
+func (b *Block) RequiredArgs() []string {
+	var names []string
+	for name, attr := range b.Attributes {
+		if attr.Required {
+			names = append(names, name)
+		}
+	}
+	for name, nb := range b.BlockTypes {
+		if nb != nil && nb.MinItems > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OptionalArgs returns the sorted names of optional attributes and nested blocks with min_items == 0,
+// computed-only attributes are excluded since they cannot be set
+func (b *Block) OptionalArgs() []string {
+	var names []string
+	for name, attr := range b.Attributes {
+		if attr.Optional {
+			names = append(names, name)
+		}
+	}
+	for name, nb := range b.BlockTypes {
+		if nb != nil && nb.MinItems == 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ComputedOnly checks whether the attribute is only set by the provider and cannot be declared in config
+func (a *Attribute) ComputedOnly() bool {
+	return a.Computed && !a.Optional && !a.Required
+}
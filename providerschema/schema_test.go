@@ -0,0 +1,69 @@
+package providerschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Load(t *testing.T) {
+	schemas, err := Load("testdata/schema.json")
+	require.NoError(t, err)
+
+	rg := schemas.Resource("azurerm_resource_group")
+	require.NotNil(t, rg)
+	assert.Equal(t, []string{"location", "name"}, rg.RequiredArgs())
+	assert.Equal(t, []string{"managed_by", "tags", "timeouts"}, rg.OptionalArgs())
+	assert.True(t, rg.Attribute("id").ComputedOnly())
+	assert.False(t, rg.Attribute("tags").ComputedOnly())
+
+	aks := schemas.BlockSchema("resource", "azurerm_kubernetes_cluster")
+	require.NotNil(t, aks)
+	assert.True(t, aks.Attribute("api_server_authorized_ip_ranges").Deprecated)
+	assert.True(t, aks.NestedBlock("default_node_pool").Attribute("enable_auto_scaling").Deprecated)
+	assert.Nil(t, aks.NestedBlock("default_node_pool", "upgrade_settings"))
+	assert.Equal(t, []string{"default_node_pool", "name"}, aks.RequiredArgs())
+
+	dataRg := schemas.BlockSchema("data", "azurerm_resource_group")
+	require.NotNil(t, dataRg)
+	assert.True(t, dataRg.Attribute("location").ComputedOnly())
+	assert.Nil(t, schemas.DataSource("azurerm_kubernetes_cluster"))
+	assert.Nil(t, schemas.BlockSchema("module", "azurerm_resource_group"))
+}
+
+func Test_NullBlockType(t *testing.T) {
+	schemas, err := Parse([]byte(`{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/azurerm": {
+      "resource_schemas": {
+        "azurerm_resource_group": {
+          "block": {
+            "attributes": {"name": {"required": true}},
+            "block_types": {"timeouts": null}
+          }
+        }
+      }
+    }
+  }
+}`))
+	require.NoError(t, err)
+
+	rg := schemas.Resource("azurerm_resource_group")
+	require.NotNil(t, rg)
+	assert.Equal(t, []string{"name"}, rg.RequiredArgs())
+	assert.Empty(t, rg.OptionalArgs())
+	assert.Nil(t, rg.NestedBlock("timeouts"))
+}
+
+func Test_LoadInvalidFile(t *testing.T) {
+	_, err := Load("testdata/not_exist.json")
+	assert.Error(t, err)
+
+	_, err = Parse([]byte(`{"format_version": "1.0"}`))
+	assert.Error(t, err)
+
+	_, err = Parse([]byte(`not json`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed arg_order_profiles.yaml
+var bundledArgOrderProfiles []byte
+
+// ArgOrderProfile declares the required and optional arguments of a resource/data type,
+// required arguments are expected to be placed before optional ones, both in alphabetic order
+type ArgOrderProfile struct {
+	Required []string `yaml:"required"`
+	Optional []string `yaml:"optional"`
+}
+
+// ArgOrderProfiles indexes the profiles by block type ("resource"/"data") and resource type
+type ArgOrderProfiles map[string]map[string]*ArgOrderProfile
+
+// LoadArgOrderProfiles loads the profiles from the given schema file, the bundled profiles are loaded if path is empty
+func LoadArgOrderProfiles(path string, wd string) (ArgOrderProfiles, error) {
+	content, name := bundledArgOrderProfiles, "bundled"
+	if path != "" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(wd, path)
+		}
+		var err error
+		// #nosec G304
+		content, err = os.ReadFile(path)
+		name = path
+		if err != nil {
+			return nil, fmt.Errorf("cannot read argument order schema file %s: %w", path, err)
+		}
+	}
+	profiles := ArgOrderProfiles{}
+	if err := yaml.Unmarshal(content, &profiles); err != nil {
+		return nil, fmt.Errorf("invalid argument order schema file %s: %w", name, err)
+	}
+	return profiles, nil
+}
+
+// Profile returns the profile of the given resource/data block, nil if not declared
+func (p ArgOrderProfiles) Profile(block *hclsyntax.Block) *ArgOrderProfile {
+	if p == nil || len(block.Labels) == 0 {
+		return nil
+	}
+	return p[block.Type][block.Labels[0]]
+}
+
+// Compare compares the expected position of argument x and argument y,
+// arguments not declared in the profile are placed at last and keep their original order
+func (p *ArgOrderProfile) Compare(x, y string) int {
+	groupX, groupY := p.group(x), p.group(y)
+	if groupX != groupY {
+		return groupX - groupY
+	}
+	if groupX == 2 {
+		return 0
+	}
+	return strings.Compare(x, y)
+}
+
+func (p *ArgOrderProfile) group(name string) int {
+	if slices.Contains(p.Required, name) {
+		return 0
+	}
+	if slices.Contains(p.Optional, name) {
+		return 1
+	}
+	return 2
+}
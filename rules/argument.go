@@ -2,6 +2,7 @@ package rules
 
 import (
 	"math"
+	"slices"
 	"sort"
 	"strings"
 
@@ -40,20 +41,27 @@ type Arg struct {
 	File  *hcl.File
 }
 
-// ToString prints the arg content
+// ToString prints the arg content with its comments
 func (a *Arg) ToString() string {
-	return string(hclwrite.Format(a.Range.SliceBytes(a.File.Bytes)))
+	txt := withComments(a.File, a.Range, string(a.Range.SliceBytes(a.File.Bytes)))
+	return string(hclwrite.Format([]byte(txt)))
 }
 
 // Args is the collection of args with the same type
 type Args struct {
-	Args  []*Arg
-	Range *hcl.Range
+	Args    []*Arg
+	Range   *hcl.Range
+	profile *ArgOrderProfile
 }
 
-// CheckOrder checks whether this type of args are sorted
+// CheckOrder checks whether this type of args are sorted, any order is accepted without profile
 func (a *Args) CheckOrder() bool {
-	return true
+	if a == nil || a.profile == nil {
+		return true
+	}
+	return slices.IsSortedFunc(a.Args, func(x, y *Arg) int {
+		return a.profile.Compare(x.Name, y.Name)
+	})
 }
 
 // ToString prints this type of args in order
@@ -66,6 +74,11 @@ func (a *Args) ToString() string {
 	sort.Slice(sortedArgs, func(i, j int) bool {
 		return sortedArgs[i].Range.Start.Line < sortedArgs[j].Range.Start.Line
 	})
+	if a.profile != nil {
+		slices.SortStableFunc(sortedArgs, func(x, y *Arg) int {
+			return a.profile.Compare(x.Name, y.Name)
+		})
+	}
 	var lines []string
 	for _, arg := range sortedArgs {
 		lines = append(lines, arg.ToString())
@@ -81,36 +94,40 @@ func (a *Args) GetRange() *hcl.Range {
 	return a.Range
 }
 
-// HeadMetaArgs is the collection of head meta args
-type HeadMetaArgs struct {
-	Args  []*Arg
-	Range *hcl.Range
+// MetaArgs is the collection of head/tail meta args
+type MetaArgs struct {
+	Args     []*Arg
+	Range    *hcl.Range
+	priority map[string]int
 }
 
-// CheckOrder checks whether the head meta args are sorted
-func (a *HeadMetaArgs) CheckOrder() bool {
+// HeadMetaArgs is the collection of head meta args
+type HeadMetaArgs = MetaArgs
+
+// CheckOrder checks whether the meta args are sorted
+func (a *MetaArgs) CheckOrder() bool {
 	if a == nil {
 		return true
 	}
 	score := math.MaxInt
 	for _, arg := range a.Args {
-		if score < headMetaArgPriority[arg.Name] {
+		if score < a.priority[arg.Name] {
 			return false
 		}
-		score = headMetaArgPriority[arg.Name]
+		score = a.priority[arg.Name]
 	}
 	return true
 }
 
-// ToString prints the head meta args in order
-func (a *HeadMetaArgs) ToString() string {
+// ToString prints the meta args in order
+func (a *MetaArgs) ToString() string {
 	if a == nil {
 		return ""
 	}
 	sortedArgs := make([]*Arg, len(a.Args))
 	copy(sortedArgs, a.Args)
-	sort.Slice(sortedArgs, func(i, j int) bool {
-		return headMetaArgPriority[sortedArgs[i].Name] > headMetaArgPriority[sortedArgs[j].Name]
+	sort.SliceStable(sortedArgs, func(i, j int) bool {
+		return a.priority[sortedArgs[i].Name] > a.priority[sortedArgs[j].Name]
 	})
 	var lines []string
 	for _, arg := range sortedArgs {
@@ -119,14 +136,120 @@ func (a *HeadMetaArgs) ToString() string {
 	return string(hclwrite.Format([]byte(strings.Join(lines, "\n"))))
 }
 
-// GetRange returns the entire range of head meta args
-func (a *HeadMetaArgs) GetRange() *hcl.Range {
+// GetRange returns the entire range of meta args
+func (a *MetaArgs) GetRange() *hcl.Range {
 	if a == nil {
 		return nil
 	}
 	return a.Range
 }
 
+// TailMetaArgs is the collection of tail meta args, both the attributes like `depends_on` and the blocks like `lifecycle`,
+// an attribute and a block next to each other are expected to be separated by a blank line
+type TailMetaArgs struct {
+	Args     []*Arg
+	Blocks   []*NestedBlock
+	Range    *hcl.Range
+	priority map[string]int
+}
+
+type tailMetaArg struct {
+	name    string
+	rng     hcl.Range
+	isBlock bool
+	txt     func() string
+}
+
+// CheckOrder checks whether the tail meta args are sorted and gaped
+func (a *TailMetaArgs) CheckOrder() bool {
+	if a == nil {
+		return true
+	}
+	args := a.tailMetaArgs()
+	for i := 1; i < len(args); i++ {
+		if a.priority[args[i-1].name] < a.priority[args[i].name] {
+			return false
+		}
+		if args[i-1].isBlock != args[i].isBlock && args[i].rng.Start.Line-args[i-1].rng.End.Line < 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// ToString prints the tail meta args in order
+func (a *TailMetaArgs) ToString() string {
+	if a == nil {
+		return ""
+	}
+	args := a.tailMetaArgs()
+	sort.SliceStable(args, func(i, j int) bool {
+		return a.priority[args[i].name] > a.priority[args[j].name]
+	})
+	var txt string
+	for i, arg := range args {
+		if i > 0 {
+			txt += "\n"
+			if args[i-1].isBlock != arg.isBlock {
+				txt += "\n"
+			}
+		}
+		txt += arg.txt()
+	}
+	return string(hclwrite.Format([]byte(txt)))
+}
+
+// GetRange returns the entire range of tail meta args
+func (a *TailMetaArgs) GetRange() *hcl.Range {
+	if a == nil {
+		return nil
+	}
+	return a.Range
+}
+
+// tailMetaArgs returns the attributes and blocks in the order of their lines
+func (a *TailMetaArgs) tailMetaArgs() []tailMetaArg {
+	var args []tailMetaArg
+	for _, arg := range a.Args {
+		args = append(args, tailMetaArg{name: arg.Name, rng: arg.Range, txt: arg.ToString})
+	}
+	for _, nb := range a.Blocks {
+		args = append(args, tailMetaArg{name: nb.Name, rng: nb.Range, isBlock: true, txt: func() string {
+			return withComments(nb.File, nb.Range, nb.ToString())
+		}})
+	}
+	sort.Slice(args, func(i, j int) bool {
+		return args[i].rng.Start.Line < args[j].rng.Start.Line
+	})
+	return args
+}
+
+func (a *TailMetaArgs) addArg(arg *Arg) {
+	a.Args = append(a.Args, arg)
+	a.updateRange(arg.Range)
+}
+
+func (a *TailMetaArgs) addBlock(nb *NestedBlock) {
+	a.Blocks = append(a.Blocks, nb)
+	a.updateRange(nb.Range)
+}
+
+func (a *TailMetaArgs) updateRange(r hcl.Range) {
+	if a.Range == nil {
+		a.Range = &hcl.Range{
+			Filename: r.Filename,
+			Start:    hcl.Pos{Line: math.MaxInt},
+			End:      hcl.Pos{Line: -1},
+		}
+	}
+	if a.Range.Start.Line > r.Start.Line {
+		a.Range.Start = r.Start
+	}
+	if a.Range.End.Line < r.End.Line {
+		a.Range.End = r.End
+	}
+}
+
 func (a *Args) add(arg *Arg) {
 	a.Args = append(a.Args, arg)
 	a.updateRange(arg)
@@ -148,12 +271,12 @@ func (a *Args) updateRange(arg *Arg) {
 	}
 }
 
-func (a *HeadMetaArgs) add(arg *Arg) {
+func (a *MetaArgs) add(arg *Arg) {
 	a.Args = append(a.Args, arg)
 	a.updateRange(arg)
 }
 
-func (a *HeadMetaArgs) updateRange(arg *Arg) {
+func (a *MetaArgs) updateRange(arg *Arg) {
 	if a.Range == nil {
 		a.Range = &hcl.Range{
 			Filename: arg.Range.Filename,
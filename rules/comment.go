@@ -0,0 +1,196 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// commentTokens returns the comments of the file in source order, the lexer tells them apart from the code,
+// so that multi-line block comments and comment markers inside strings are handled
+func commentTokens(file *hcl.File) hclsyntax.Tokens {
+	tokens, _ := hclsyntax.LexConfig(file.Bytes, "", hcl.InitialPos)
+	var comments hclsyntax.Tokens
+	for _, token := range tokens {
+		if token.Type == hclsyntax.TokenComment {
+			comments = append(comments, token)
+		}
+	}
+	return comments
+}
+
+// attachedComments returns the comments attached to the given range, which are the comments placed on their own lines
+// directly above it without blank line in between, and the inline comments following it on its last line
+func attachedComments(file *hcl.File, comments hclsyntax.Tokens, r hcl.Range) (leading, trailing hclsyntax.Tokens) {
+	src := file.Bytes
+	end := r.End.Byte
+	for i := sort.Search(len(comments), func(i int) bool {
+		return comments[i].Range.Start.Byte >= r.End.Byte
+	}); i < len(comments); i++ {
+		comment := comments[i]
+		if comment.Range.Start.Line != r.End.Line || len(bytes.TrimSpace(src[end:comment.Range.Start.Byte])) != 0 {
+			break
+		}
+		trailing = append(trailing, comment)
+		end = comment.Range.End.Byte
+	}
+	if !startsLine(src, r.Start.Byte) {
+		return nil, trailing
+	}
+	line := r.Start.Line
+	for j := sort.Search(len(comments), func(j int) bool {
+		return comments[j].Range.Start.Byte >= r.Start.Byte
+	}) - 1; j >= 0; j-- {
+		comment := comments[j]
+		if commentEndLine(comment) != line-1 || !startsLine(src, comment.Range.Start.Byte) || !endsLine(src, comment) {
+			break
+		}
+		leading = append(hclsyntax.Tokens{comment}, leading...)
+		line = comment.Range.Start.Line
+	}
+	return leading, trailing
+}
+
+// commentEndLine returns the last line of the comment, the newline terminating a line comment is part of its token
+func commentEndLine(comment hclsyntax.Token) int {
+	if bytes.HasSuffix(comment.Bytes, []byte("\n")) {
+		return comment.Range.End.Line - 1
+	}
+	return comment.Range.End.Line
+}
+
+// commentText returns the text of the comment without the terminating newline
+func commentText(comment hclsyntax.Token) string {
+	return strings.TrimRight(string(comment.Bytes), "\r\n")
+}
+
+// startsLine checks whether there is only whitespace between the start of the line and the offset
+func startsLine(src []byte, offset int) bool {
+	lineStart := bytes.LastIndexByte(src[:offset], '\n') + 1
+	return len(bytes.TrimSpace(src[lineStart:offset])) == 0
+}
+
+// endsLine checks whether there is only whitespace between the end of the comment and the end of the line
+func endsLine(src []byte, comment hclsyntax.Token) bool {
+	rest := src[comment.Range.End.Byte:]
+	if bytes.HasSuffix(comment.Bytes, []byte("\n")) {
+		return true
+	}
+	if lineEnd := bytes.IndexByte(rest, '\n'); lineEnd >= 0 {
+		rest = rest[:lineEnd]
+	}
+	return len(bytes.TrimSpace(rest)) == 0
+}
+
+// danglingComments returns the comments in the block body which aren't attached to any argument or nested block,
+// the ones above the first entry are returned as head, the others, e.g. the ones before the closing brace, as tail
+func danglingComments(file *hcl.File, block *hclsyntax.Block) (head, tail string) {
+	var ranges []hcl.Range
+	for _, attr := range block.Body.Attributes {
+		ranges = append(ranges, attr.SrcRange)
+	}
+	for _, nb := range block.Body.Blocks {
+		ranges = append(ranges, nb.Range())
+	}
+	comments := commentTokens(file)
+	attached := make(map[int]bool)
+	// the inline comments following the opening brace stay with the block head
+	_, braceComments := attachedComments(file, comments, block.OpenBraceRange)
+	for _, comment := range braceComments {
+		attached[comment.Range.Start.Byte] = true
+	}
+	firstEntry := block.CloseBraceRange.Start.Byte
+	for _, r := range ranges {
+		firstEntry = min(firstEntry, r.Start.Byte)
+		leading, trailing := attachedComments(file, comments, r)
+		for _, comment := range append(leading, trailing...) {
+			attached[comment.Range.Start.Byte] = true
+		}
+	}
+	var headComments, tailComments []string
+	for _, comment := range comments {
+		offset := comment.Range.Start.Byte
+		if offset < block.OpenBraceRange.End.Byte || offset >= block.CloseBraceRange.Start.Byte || attached[offset] {
+			continue
+		}
+		inEntry := false
+		for _, r := range ranges {
+			inEntry = inEntry || r.ContainsOffset(offset)
+		}
+		switch {
+		case inEntry:
+		case offset < firstEntry:
+			headComments = append(headComments, commentText(comment))
+		default:
+			tailComments = append(tailComments, commentText(comment))
+		}
+	}
+	return strings.Join(headComments, "\n"), strings.Join(tailComments, "\n")
+}
+
+// blockText prints the block with the given body, the inline comments following the opening brace stay on its line
+func blockText(file *hcl.File, block *hclsyntax.Block, body string) string {
+	head := string(block.DefRange().SliceBytes(file.Bytes))
+	_, braceComments := attachedComments(file, commentTokens(file), block.OpenBraceRange)
+	if len(braceComments) > 0 {
+		head = fmt.Sprintf("%s { %s", head, joinComments(braceComments))
+		if strings.TrimSpace(body) == "" {
+			return fmt.Sprintf("%s\n}", head)
+		}
+		return fmt.Sprintf("%s\n%s\n}", head, body)
+	}
+	if strings.TrimSpace(body) == "" {
+		return fmt.Sprintf("%s {}", head)
+	}
+	return fmt.Sprintf("%s {\n%s\n}", head, body)
+}
+
+// joinComments joins the comments following each other on a line
+func joinComments(comments hclsyntax.Tokens) string {
+	var texts []string
+	for _, comment := range comments {
+		texts = append(texts, commentText(comment))
+	}
+	return strings.Join(texts, " ")
+}
+
+// rangeWithComments extends the given range to cover the comments attached to it
+func rangeWithComments(file *hcl.File, r hcl.Range) hcl.Range {
+	leading, trailing := attachedComments(file, commentTokens(file), r)
+	start := r.Start
+	if len(leading) > 0 {
+		start = leading[0].Range.Start
+	}
+	end := r.End
+	if len(trailing) > 0 {
+		last := trailing[len(trailing)-1]
+		end = last.Range.End
+		if text := commentText(last); len(text) < len(last.Bytes) {
+			// a line comment, exclude the terminating newline
+			end = hcl.Pos{
+				Line:   last.Range.Start.Line,
+				Column: last.Range.Start.Column + utf8.RuneCountInString(text),
+				Byte:   last.Range.Start.Byte + len(text),
+			}
+		}
+	}
+	return hcl.Range{Filename: r.Filename, Start: start, End: end}
+}
+
+// withComments wraps the text of an argument with its attached comments
+func withComments(file *hcl.File, r hcl.Range, txt string) string {
+	leading, trailing := attachedComments(file, commentTokens(file), r)
+	if len(trailing) > 0 {
+		txt = txt + " " + joinComments(trailing)
+	}
+	var lines []string
+	for _, comment := range leading {
+		lines = append(lines, commentText(comment))
+	}
+	return strings.Join(append(lines, txt), "\n")
+}
@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = &configurableRule{}
+
+// configurableRule wraps every rule of the ruleset to apply the options shared by all rules,
+// `severity` and `message_prefix`, which can be set in any `rule` block, and the suppression comments
+type configurableRule struct {
+	tflint.Rule
+	severity tflint.Severity
+}
+
+// ruleOptions are the options shared by all rules
+type ruleOptions struct {
+	Severity      string
+	MessagePrefix string
+}
+
+// ruleOptionFields are appended to the config struct of a rule to decode the shared options along with the rule's own ones
+var ruleOptionFields = []reflect.StructField{
+	{Name: "BasicExtSeverity", Type: reflect.TypeOf(""), Tag: `hclext:"severity,optional"`},
+	{Name: "BasicExtMessagePrefix", Type: reflect.TypeOf(""), Tag: `hclext:"message_prefix,optional"`},
+}
+
+var severities = map[string]tflint.Severity{
+	"error":   tflint.ERROR,
+	"warning": tflint.WARNING,
+	"notice":  tflint.NOTICE,
+}
+
+func newConfigurableRule(rule tflint.Rule, severity tflint.Severity) *configurableRule {
+	if wrapped, ok := rule.(*configurableRule); ok {
+		rule = wrapped.Rule
+	}
+	return &configurableRule{Rule: rule, severity: severity}
+}
+
+// Severity returns the default severity of the rule, either the one of the wrapped rule or of the preset
+func (r *configurableRule) Severity() tflint.Severity {
+	return r.severity
+}
+
+// Check checks the wrapped rule, the shared options are decoded when the rule decodes its own config
+func (r *configurableRule) Check(runner tflint.Runner) error {
+	optionsRunner := &ruleOptionsRunner{Runner: runner, rule: r}
+	if err := r.Rule.Check(optionsRunner); err != nil {
+		return err
+	}
+	// the rule has no config of its own, decode the shared options to report the invalid ones
+	_, err := optionsRunner.ruleOptions()
+	return err
+}
+
+// ruleOptionsRunner emits the issues of a wrapped rule with the shared options applied, unless they are suppressed by comments
+type ruleOptionsRunner struct {
+	tflint.Runner
+	rule         *configurableRule
+	options      *ruleOptions
+	suppressions map[string][]suppression
+}
+
+// DecodeRuleConfig decodes the config of the rule along with the shared options
+func (r *ruleOptionsRunner) DecodeRuleConfig(name string, ret interface{}) error {
+	options, err := decodeRuleConfigWithOptions(r.Runner, name, ret)
+	if err != nil {
+		return err
+	}
+	r.options = options
+	return nil
+}
+
+// EmitIssue emits the issue with the configured severity and message prefix
+func (r *ruleOptionsRunner) EmitIssue(_ tflint.Rule, message string, location hcl.Range) error {
+	if suppressed, err := r.suppressed(location); suppressed || err != nil {
+		return err
+	}
+	rule, message, err := r.issue(message)
+	if err != nil {
+		return err
+	}
+	return r.Runner.EmitIssue(rule, message, location)
+}
+
+// EmitIssueWithFix emits the issue with the fix with the configured severity and message prefix
+func (r *ruleOptionsRunner) EmitIssueWithFix(_ tflint.Rule, message string, location hcl.Range, fixFunc func(f tflint.Fixer) error) error {
+	if suppressed, err := r.suppressed(location); suppressed || err != nil {
+		return err
+	}
+	rule, message, err := r.issue(message)
+	if err != nil {
+		return err
+	}
+	return r.Runner.EmitIssueWithFix(rule, message, location, fixFunc)
+}
+
+// suppressed checks whether the issue at the location is suppressed by a comment in its file
+func (r *ruleOptionsRunner) suppressed(location hcl.Range) (bool, error) {
+	if location.Filename == "" {
+		return false, nil
+	}
+	suppressions, ok := r.suppressions[location.Filename]
+	if !ok {
+		file, err := r.Runner.GetFile(location.Filename)
+		if err != nil {
+			return false, err
+		}
+		if file != nil {
+			suppressions = collectSuppressions(file)
+		}
+		if r.suppressions == nil {
+			r.suppressions = make(map[string][]suppression)
+		}
+		r.suppressions[location.Filename] = suppressions
+	}
+	for _, s := range suppressions {
+		if s.Rule == r.rule.Name() && s.covers(location) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *ruleOptionsRunner) issue(message string) (tflint.Rule, string, error) {
+	options, err := r.ruleOptions()
+	if err != nil {
+		return nil, "", err
+	}
+	rule := r.rule
+	if options.Severity != "" {
+		rule = &configurableRule{Rule: r.rule.Rule, severity: severities[strings.ToLower(options.Severity)]}
+	}
+	if options.MessagePrefix != "" {
+		message = fmt.Sprintf("%s %s", options.MessagePrefix, message)
+	}
+	return rule, message, nil
+}
+
+// ruleOptions returns the shared options, they are decoded alone if the rule didn't decode its config
+func (r *ruleOptionsRunner) ruleOptions() (*ruleOptions, error) {
+	if r.options != nil {
+		return r.options, nil
+	}
+	if err := r.DecodeRuleConfig(r.rule.Name(), &struct{}{}); err != nil {
+		return nil, err
+	}
+	return r.options, nil
+}
+
+// decodeRuleConfigWithOptions decodes the rule config into ret and returns the shared options set in the same `rule` block
+func decodeRuleConfigWithOptions(runner tflint.Runner, name string, ret interface{}) (*ruleOptions, error) {
+	config := reflect.ValueOf(ret).Elem()
+	var fields []reflect.StructField
+	for i := 0; i < config.NumField(); i++ {
+		fields = append(fields, config.Type().Field(i))
+	}
+	merged := reflect.New(reflect.StructOf(append(fields, ruleOptionFields...))).Elem()
+	if err := runner.DecodeRuleConfig(name, merged.Addr().Interface()); err != nil {
+		return nil, err
+	}
+	for i := 0; i < config.NumField(); i++ {
+		config.Field(i).Set(merged.Field(i))
+	}
+	options := &ruleOptions{
+		Severity:      merged.Field(len(fields)).String(),
+		MessagePrefix: merged.Field(len(fields) + 1).String(),
+	}
+	if _, ok := severities[strings.ToLower(options.Severity)]; options.Severity != "" && !ok {
+		return nil, fmt.Errorf("invalid severity `%s` of rule `%s`, expected one of `error`, `warning` or `notice`", options.Severity, name)
+	}
+	return options, nil
+}
@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_ConfigurableRule(t *testing.T) {
+	cases := []struct {
+		Name             string
+		Rule             tflint.Rule
+		Config           string
+		Content          string
+		ExpectedSeverity tflint.Severity
+		ExpectedMessage  string
+	}{
+		{
+			Name: "1. rule without options",
+			Rule: NewTerraformVariableTypeRequiredRule(),
+			Content: `
+variable "image_id" {}`,
+			ExpectedSeverity: tflint.WARNING,
+			ExpectedMessage:  "`type` is required for variable `image_id`",
+		},
+		{
+			Name: "2. options along with the rule config",
+			Rule: NewTerraformVariableTypeRequiredRule(),
+			Config: `
+rule "terraform_variable_type_required" {
+  enabled        = true
+  allow_any      = true
+  severity       = "error"
+  message_prefix = "[shared-module]"
+}`,
+			Content: `
+variable "image_id" {}
+
+variable "anything" {
+  type = any
+}`,
+			ExpectedSeverity: tflint.ERROR,
+			ExpectedMessage:  "[shared-module] `type` is required for variable `image_id`",
+		},
+		{
+			Name: "3. options of rule without config",
+			Rule: NewTerraformVariableOrderRule(),
+			Config: `
+rule "terraform_variable_order" {
+  enabled  = true
+  severity = "Warning"
+}`,
+			Content: `
+variable "b" {}
+
+variable "a" {}`,
+			ExpectedSeverity: tflint.WARNING,
+			ExpectedMessage:  "Recommended variable order:\nvariable \"a\" {}\n\nvariable \"b\" {}",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"variables.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+			rule := newConfigurableRule(tc.Rule, tc.Rule.Severity())
+			require.NoError(t, rule.Check(runner))
+			require.Len(t, runner.Issues, 1)
+			assert.Equal(t, tc.Rule.Name(), runner.Issues[0].Rule.Name())
+			assert.Equal(t, tc.ExpectedSeverity, runner.Issues[0].Rule.Severity())
+			assert.Equal(t, tc.ExpectedMessage, runner.Issues[0].Message)
+		})
+	}
+}
+
+func Test_ConfigurableRule_InvalidSeverity(t *testing.T) {
+	for _, rule := range []tflint.Rule{NewTerraformVariableTypeRequiredRule(), NewTerraformVariableOrderRule()} {
+		runner := helper.TestRunner(t, map[string]string{
+			".tflint.hcl": `
+rule "` + rule.Name() + `" {
+  enabled  = true
+  severity = "fatal"
+}`,
+			"variables.tf": `
+variable "a" {}`,
+		})
+		err := newConfigurableRule(rule, rule.Severity()).Check(runner)
+		assert.EqualError(t, err, "invalid severity `fatal` of rule `"+rule.Name()+"`, expected one of `error`, `warning` or `notice`")
+	}
+}
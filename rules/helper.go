@@ -7,36 +7,106 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"slices"
 	"sort"
 	"strings"
 )
 
-var headMetaArgPriority = map[string]int{"for_each": 0, "count": 0, "provider": 1}
-var tailMetaArgPriority = map[string]int{"lifecycle": 0, "depends_on": 1}
+var metaArgNames = []string{"count", "depends_on", "for_each", "lifecycle", "provider"}
+
+// MetaArgLayout declares which meta args are placed at the head/tail of a block,
+// the meta arg with higher priority is expected to be placed earlier
+type MetaArgLayout struct {
+	HeadMetaArgPriority map[string]int
+	TailMetaArgPriority map[string]int
+}
+
+// DefaultMetaArgLayout puts for_each/count/provider at the head and lifecycle/depends_on at the tail
+var DefaultMetaArgLayout = &MetaArgLayout{
+	HeadMetaArgPriority: map[string]int{"for_each": 0, "count": 0, "provider": 1},
+	TailMetaArgPriority: map[string]int{"lifecycle": 0, "depends_on": 1},
+}
+
+// NewMetaArgLayout builds a layout with the given priority tables, the default table is used if a table is nil
+func NewMetaArgLayout(headMetaArgPriority, tailMetaArgPriority map[string]int) (*MetaArgLayout, error) {
+	if headMetaArgPriority == nil {
+		headMetaArgPriority = DefaultMetaArgLayout.HeadMetaArgPriority
+	}
+	if tailMetaArgPriority == nil {
+		tailMetaArgPriority = DefaultMetaArgLayout.TailMetaArgPriority
+	}
+	var err error
+	for _, table := range []map[string]int{headMetaArgPriority, tailMetaArgPriority} {
+		for name := range table {
+			if !slices.Contains(metaArgNames, name) {
+				err = multierror.Append(err, fmt.Errorf("`%s` is not a meta argument, expected one of %s", name, strings.Join(metaArgNames, ", ")))
+			}
+		}
+	}
+	for name := range headMetaArgPriority {
+		if _, conflict := tailMetaArgPriority[name]; conflict {
+			err = multierror.Append(err, fmt.Errorf("`%s` cannot be declared as both head and tail meta argument", name))
+		}
+	}
+	if _, isHeadMeta := headMetaArgPriority["lifecycle"]; isHeadMeta {
+		err = multierror.Append(err, fmt.Errorf("`lifecycle` is a nested block and can only be declared as tail meta argument"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &MetaArgLayout{
+		HeadMetaArgPriority: headMetaArgPriority,
+		TailMetaArgPriority: tailMetaArgPriority,
+	}, nil
+}
+
+// IsHeadMeta checks whether a name represents a type of head Meta arg in the layout
+func (l *MetaArgLayout) IsHeadMeta(argName string) bool {
+	_, isHeadMeta := l.HeadMetaArgPriority[argName]
+	return isHeadMeta
+}
+
+// IsTailMeta checks whether a name represents a type of tail Meta arg in the layout
+func (l *MetaArgLayout) IsTailMeta(argName string) bool {
+	_, isTailMeta := l.TailMetaArgPriority[argName]
+	return isTailMeta
+}
 
 // IsHeadMeta checks whether a name represents a type of head Meta arg
 func IsHeadMeta(argName string) bool {
-	_, isHeadMeta := headMetaArgPriority[argName]
-	return isHeadMeta
+	return DefaultMetaArgLayout.IsHeadMeta(argName)
 }
 
 // IsTailMeta checks whether a name represents a type of tail Meta arg
 func IsTailMeta(argName string) bool {
-	_, isTailMeta := tailMetaArgPriority[argName]
-	return isTailMeta
+	return DefaultMetaArgLayout.IsTailMeta(argName)
 }
 
 func ref(hr hcl.Range) *hcl.Range {
 	return &hr
 }
 
+// ForFiles calls the action for each HCL file of the module, use Walker to visit the blocks, attributes and expressions of the files
 func ForFiles(runner tflint.Runner, action func(tflint.Runner, *hcl.File) error) error {
-	files, err := runner.GetFiles()
-	if err != nil {
-		return err
+	walker := &Walker{
+		IncludeOverrideFiles: true,
+		File: func(ctx *WalkContext) error {
+			return action(ctx.Runner, ctx.File)
+		},
 	}
-	for _, file := range files {
-		if subErr := action(runner, file); subErr != nil {
+	return walker.Walk(runner)
+}
+
+// ReorderWithComments rewrites the entries at the given ranges so that they appear in the sorted order,
+// comments attached to the entries are moved along with them
+func ReorderWithComments(f tflint.Fixer, file *hcl.File, ranges []hcl.Range, sortedRanges []hcl.Range) error {
+	var err error
+	for i, r := range ranges {
+		if r == sortedRanges[i] {
+			continue
+		}
+		sortedTxt := string(rangeWithComments(file, sortedRanges[i]).SliceBytes(file.Bytes))
+		if subErr := f.ReplaceText(rangeWithComments(file, r), sortedTxt); subErr != nil {
 			err = multierror.Append(err, subErr)
 		}
 	}
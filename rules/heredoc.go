@@ -0,0 +1,450 @@
+package rules
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	heredocPlaceholder = regexp.MustCompile(`__heredoc_interp_(\d+)__`)
+	jsonNumber         = regexp.MustCompile(`^-?(0|[1-9]\d*)(\.\d+)?([eE][+-]?\d+)?$`)
+)
+
+// heredocTemplate is a heredoc collected from the tokens of a file
+type heredocTemplate struct {
+	// StartRange is the range of the opening marker, e.g. `<<-JSON`
+	StartRange hcl.Range
+	// Range covers the heredoc from the opening marker to the closing marker
+	Range hcl.Range
+	// Content is the text of the heredoc with every interpolation substituted by a placeholder
+	Content string
+	// Interpolations are the source texts of the expressions in `${...}`, indexed by placeholder
+	Interpolations []string
+	// HasDirective reports whether template directives like `%{if}` are used
+	HasDirective bool
+	// Flush reports whether the heredoc is a flush heredoc, i.e. `<<-`
+	Flush bool
+}
+
+// collectHeredocs returns the heredocs in the tokens of a file
+func collectHeredocs(src []byte, tokens hclsyntax.Tokens) []*heredocTemplate {
+	var heredocs []*heredocTemplate
+	var heredoc *heredocTemplate
+	var content strings.Builder
+	// depth is the nesting level of the template sequences inside the heredoc
+	depth := 0
+	interpStart := 0
+	for _, token := range tokens {
+		if heredoc == nil {
+			if token.Type == hclsyntax.TokenOHeredoc {
+				heredoc = &heredocTemplate{
+					StartRange: token.Range,
+					Flush:      strings.HasPrefix(string(token.Bytes), "<<-"),
+				}
+				content.Reset()
+			}
+			continue
+		}
+		if depth > 0 {
+			switch token.Type {
+			case hclsyntax.TokenTemplateInterp, hclsyntax.TokenTemplateControl:
+				depth++
+			case hclsyntax.TokenTemplateSeqEnd:
+				depth--
+				if depth == 0 && interpStart >= 0 {
+					expr := strings.Trim(string(src[interpStart:token.Range.Start.Byte]), " \t\n~")
+					content.WriteString(fmt.Sprintf("__heredoc_interp_%d__", len(heredoc.Interpolations)))
+					heredoc.Interpolations = append(heredoc.Interpolations, expr)
+				}
+			}
+			continue
+		}
+		switch token.Type {
+		case hclsyntax.TokenTemplateInterp:
+			depth = 1
+			interpStart = token.Range.End.Byte
+		case hclsyntax.TokenTemplateControl:
+			depth = 1
+			interpStart = -1
+			heredoc.HasDirective = true
+		case hclsyntax.TokenStringLit:
+			content.WriteString(unescapeTemplateLiteral(string(token.Bytes)))
+		case hclsyntax.TokenCHeredoc:
+			heredoc.Range = hcl.RangeBetween(heredoc.StartRange, token.Range)
+			heredoc.Content = content.String()
+			if heredoc.Flush {
+				heredoc.Content = dedent(heredoc.Content)
+			}
+			heredocs = append(heredocs, heredoc)
+			heredoc = nil
+		}
+	}
+	return heredocs
+}
+
+func unescapeTemplateLiteral(s string) string {
+	s = strings.ReplaceAll(s, "$${", "${")
+	return strings.ReplaceAll(s, "%%{", "%{")
+}
+
+// dedent removes the common leading whitespace of the non-blank lines like a flush heredoc does
+func dedent(s string) string {
+	lines := strings.Split(s, "\n")
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent <= 0 {
+		return s
+	}
+	for i, line := range lines {
+		if len(line) >= indent {
+			lines[i] = line[indent:]
+		} else {
+			lines[i] = strings.TrimLeft(line, " \t")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ToEncodeCall converts the heredoc into an equivalent call of the given encode function, e.g. `jsonencode({...})`,
+// it fails if the heredoc uses template directives or can't be represented as an HCL expression
+func (h *heredocTemplate) ToEncodeCall(function string) (string, error) {
+	if h.HasDirective {
+		return "", errors.New("template directives can't be converted")
+	}
+	decoder := yaml.NewDecoder(strings.NewReader(h.Content))
+	var document yaml.Node
+	if err := decoder.Decode(&document); err != nil {
+		return "", err
+	}
+	if err := decoder.Decode(&yaml.Node{}); err != io.EOF {
+		return "", errors.New("multiple documents can't be converted")
+	}
+	if len(document.Content) != 1 {
+		return "", errors.New("empty document can't be converted")
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode && root.Kind != yaml.SequenceNode {
+		return "", errors.New("only objects and arrays can be converted")
+	}
+	expr, err := h.nodeToHCL(root)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s(%s)", function, expr), nil
+}
+
+// yamlCoreTags are the tags which can be converted, values with other tags, e.g. `!Ref` or `!!binary`, would lose their meaning
+var yamlCoreTags = map[string]bool{
+	"!!map":       true,
+	"!!seq":       true,
+	"!!str":       true,
+	"!!null":      true,
+	"!!bool":      true,
+	"!!int":       true,
+	"!!float":     true,
+	"!!timestamp": true,
+}
+
+func (h *heredocTemplate) nodeToHCL(node *yaml.Node) (string, error) {
+	if !yamlCoreTags[node.ShortTag()] {
+		return "", fmt.Errorf("tag `%s` can't be converted", node.ShortTag())
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			return "{}", nil
+		}
+		var items []string
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, err := h.keyToHCL(node.Content[i])
+			if err != nil {
+				return "", err
+			}
+			value, err := h.nodeToHCL(node.Content[i+1])
+			if err != nil {
+				return "", err
+			}
+			items = append(items, fmt.Sprintf("%s = %s", key, value))
+		}
+		return fmt.Sprintf("{\n%s\n}", strings.Join(items, "\n")), nil
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			return "[]", nil
+		}
+		var items []string
+		for _, item := range node.Content {
+			value, err := h.nodeToHCL(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, value+",")
+		}
+		return fmt.Sprintf("[\n%s\n]", strings.Join(items, "\n")), nil
+	case yaml.ScalarNode:
+		return h.scalarToHCL(node)
+	}
+	return "", fmt.Errorf("%s can't be converted", node.ShortTag())
+}
+
+func (h *heredocTemplate) keyToHCL(node *yaml.Node) (string, error) {
+	if node.Kind != yaml.ScalarNode || node.ShortTag() == "!!merge" {
+		return "", errors.New("only scalar keys can be converted")
+	}
+	if !yamlCoreTags[node.ShortTag()] {
+		return "", fmt.Errorf("tag `%s` can't be converted", node.ShortTag())
+	}
+	if expr, ok := h.placeholderExpr(node.Value); ok {
+		return fmt.Sprintf("(%s)", expr), nil
+	}
+	if hclsyntax.ValidIdentifier(node.Value) && !heredocPlaceholder.MatchString(node.Value) {
+		return node.Value, nil
+	}
+	return h.quote(node.Value), nil
+}
+
+func (h *heredocTemplate) scalarToHCL(node *yaml.Node) (string, error) {
+	// a quoted placeholder, e.g. `"${var.port}"`, renders a string whatever the type of the expression is
+	if expr, ok := h.placeholderExpr(node.Value); ok && node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) == 0 {
+		return encodedValue(expr), nil
+	}
+	if heredocPlaceholder.MatchString(node.Value) {
+		return h.quote(node.Value), nil
+	}
+	switch node.ShortTag() {
+	case "!!null":
+		return "null", nil
+	case "!!bool":
+		return strings.ToLower(node.Value), nil
+	case "!!int", "!!float":
+		// numbers like `0x1F`, `0o17` or `.inf` have no equivalent HCL number, quoting them would turn them into strings
+		if !jsonNumber.MatchString(node.Value) {
+			return "", fmt.Errorf("number `%s` can't be converted", node.Value)
+		}
+		return node.Value, nil
+	}
+	// plain numbers out of the range of YAML integers, e.g. `995815895020119788889`, are resolved as strings
+	if node.Style == 0 && jsonNumber.MatchString(node.Value) {
+		return node.Value, nil
+	}
+	return h.quote(node.Value), nil
+}
+
+// placeholderExpr returns the interpolated expression if the value is exactly one placeholder
+func (h *heredocTemplate) placeholderExpr(value string) (string, bool) {
+	match := heredocPlaceholder.FindStringSubmatchIndex(value)
+	if match == nil || match[0] != 0 || match[1] != len(value) {
+		return "", false
+	}
+	var index int
+	_, _ = fmt.Sscanf(value[match[2]:match[3]], "%d", &index)
+	return h.Interpolations[index], true
+}
+
+// encodedValue returns the argument of an encode call like `jsonencode(x)`, the encode call wrapping the converted heredoc
+// encodes the value again
+func encodedValue(expr string) string {
+	parsed, diags := hclsyntax.ParseExpression([]byte(expr), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return expr
+	}
+	call, ok := parsed.(*hclsyntax.FunctionCallExpr)
+	if !ok || (call.Name != "jsonencode" && call.Name != "yamlencode") || len(call.Args) != 1 || call.ExpandFinal {
+		return expr
+	}
+	return string(call.Args[0].Range().SliceBytes([]byte(expr)))
+}
+
+// quote returns an HCL string literal of the value, placeholders are turned back into interpolations
+func (h *heredocTemplate) quote(value string) string {
+	quoted := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+		"${", "$${",
+		"%{", "%%{",
+	).Replace(value)
+	quoted = heredocPlaceholder.ReplaceAllStringFunc(quoted, func(placeholder string) string {
+		expr, _ := h.placeholderExpr(placeholder)
+		return fmt.Sprintf("${%s}", expr)
+	})
+	return fmt.Sprintf(`"%s"`, quoted)
+}
+
+// heredoc formats that can be detected, the order is the order of detection
+const (
+	heredocFormatPolicy = "policy"
+	heredocFormatJSON   = "json"
+	heredocFormatYAML   = "yaml"
+	heredocFormatXML    = "xml"
+	heredocFormatTOML   = "toml"
+)
+
+var heredocFormats = []string{heredocFormatPolicy, heredocFormatJSON, heredocFormatYAML, heredocFormatXML, heredocFormatTOML}
+
+var (
+	tomlKey        = `(?:[A-Za-z0-9_-]+|"[^"]*"|'[^']*')(?:\s*\.\s*(?:[A-Za-z0-9_-]+|"[^"]*"|'[^']*'))*`
+	tomlTable      = regexp.MustCompile(`^\[\[?\s*` + tomlKey + `\s*\]\]?\s*(#.*)?$`)
+	tomlKeyValue   = regexp.MustCompile(`^` + tomlKey + `\s*=\s*(.+)$`)
+	tomlLiteralStr = regexp.MustCompile(`'[^']*'`)
+	trailingCommas = regexp.MustCompile(`,(\s*[\]}])`)
+)
+
+// Format returns the first of the given formats the heredoc is written in, or an empty string if there is none
+func (h *heredocTemplate) Format(formats []string) string {
+	if RemoveSpaceAndLine(h.Content) == "" {
+		return ""
+	}
+	for _, format := range heredocFormats {
+		if !slices.Contains(formats, format) {
+			continue
+		}
+		var matched bool
+		switch format {
+		case heredocFormatPolicy:
+			matched = h.isPolicy()
+		case heredocFormatJSON:
+			matched = json.Valid([]byte(h.jsonContent()))
+		case heredocFormatYAML:
+			matched = h.isYAML()
+		case heredocFormatXML:
+			matched = h.isXML()
+		case heredocFormatTOML:
+			matched = h.isTOML()
+		}
+		if matched {
+			return format
+		}
+	}
+	return ""
+}
+
+// jsonContent returns the content with the placeholders outside of strings substituted by `null`,
+// so that `"count": ${var.count}` is still valid JSON. The trailing commas left by directives like
+// `%{ for }` are removed since the rendered document doesn't have them
+func (h *heredocTemplate) jsonContent() string {
+	content := substituteBarePlaceholders(h.Content, "null")
+	if h.HasDirective {
+		content = trailingCommas.ReplaceAllString(content, "$1")
+	}
+	return content
+}
+
+// isPolicy checks whether the heredoc is an AWS IAM policy or an Azure Policy rule written in JSON
+func (h *heredocTemplate) isPolicy() bool {
+	var document map[string]any
+	if err := json.Unmarshal([]byte(h.jsonContent()), &document); err != nil {
+		return false
+	}
+	if _, ok := document["Statement"]; ok {
+		return true
+	}
+	if _, ok := document["policyRule"]; ok {
+		return true
+	}
+	_, hasIf := document["if"]
+	_, hasThen := document["then"]
+	return hasIf && hasThen
+}
+
+func (h *heredocTemplate) isYAML() bool {
+	temp := map[string]interface{}{}
+	return yaml.Unmarshal([]byte(h.Content), &temp) == nil
+}
+
+func (h *heredocTemplate) isXML() bool {
+	if !strings.HasPrefix(strings.TrimSpace(h.Content), "<") {
+		return false
+	}
+	decoder := xml.NewDecoder(strings.NewReader(h.Content))
+	hasElement := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return hasElement
+		}
+		if err != nil {
+			return false
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			hasElement = true
+		}
+	}
+}
+
+// isTOML checks whether every line of the heredoc is a comment, a table header or a single line `key = value` pair
+func (h *heredocTemplate) isTOML() bool {
+	content := substituteBarePlaceholders(h.Content, "0")
+	pairs := 0
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || tomlTable.MatchString(line) {
+			continue
+		}
+		match := tomlKeyValue.FindStringSubmatch(line)
+		if match == nil || !isTOMLValue(match[1]) {
+			return false
+		}
+		pairs++
+	}
+	return pairs > 0
+}
+
+// isTOMLValue checks whether the value is a literal, TOML values share the syntax of HCL literals once literal strings like 'a' are quoted
+func isTOMLValue(value string) bool {
+	value = tomlLiteralStr.ReplaceAllStringFunc(value, func(s string) string {
+		return strconv.Quote(strings.Trim(s, "'"))
+	})
+	expr, diags := hclsyntax.ParseExpression([]byte(value), "", hcl.InitialPos)
+	if diags.HasErrors() || len(expr.Variables()) > 0 {
+		return false
+	}
+	_, diags = expr.Value(nil)
+	return !diags.HasErrors()
+}
+
+// substituteBarePlaceholders substitutes the placeholders outside of double-quoted strings with the replacement
+func substituteBarePlaceholders(content, replacement string) string {
+	var sb strings.Builder
+	inString := false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case inString && c == '\\' && i+1 < len(content):
+			sb.WriteByte(c)
+			i++
+			sb.WriteByte(content[i])
+			continue
+		case c == '"':
+			inString = !inString
+		case !inString && c == '_':
+			if loc := heredocPlaceholder.FindStringIndex(content[i:]); loc != nil && loc[0] == 0 {
+				sb.WriteString(replacement)
+				i += loc[1] - 1
+				continue
+			}
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
@@ -1,8 +1,8 @@
 package rules
 
 import (
-	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 
@@ -24,6 +24,7 @@ type NestedBlock struct {
 	NestedBlocks     *NestedBlocks
 	ParentBlockNames []string
 	emit             func(block Block) error
+	layout           *MetaArgLayout
 }
 
 // CheckBlock checks the nestedBlock recursively to find the block not in order,
@@ -49,6 +50,11 @@ func (b *NestedBlock) DefRange() hcl.Range {
 	return b.Block.DefRange()
 }
 
+// GetRange gets the entire range of the nested block
+func (b *NestedBlock) GetRange() hcl.Range {
+	return b.Block.Range()
+}
+
 // CheckOrder checks whether the nestedBlock is sorted
 func (b *NestedBlock) CheckOrder() bool {
 	return b.checkSubSectionOrder() && b.checkGap()
@@ -59,31 +65,32 @@ func (b *NestedBlock) ToString() string {
 	headMeta := toString(b.HeadMetaArgs)
 	args := toString(b.Args)
 	nb := toString(b.NestedBlocks)
+	headDangling, dangling := danglingComments(b.File, b.Block)
 	var codes []string
-	for _, c := range []string{headMeta, args, nb} {
+	for _, c := range []string{headDangling, headMeta, args, nb, dangling} {
 		if c != "" {
 			codes = append(codes, c)
 		}
 	}
-	code := strings.Join(codes, "\n\n")
-	blockHead := string(b.Block.DefRange().SliceBytes(b.File.Bytes))
-	if strings.TrimSpace(code) == "" {
-		code = fmt.Sprintf("%s {}", blockHead)
-	} else {
-		code = fmt.Sprintf("%s {\n%s\n}", blockHead, code)
-	}
+	code := blockText(b.File, b.Block, strings.Join(codes, "\n\n"))
 	return string(hclwrite.Format([]byte(code)))
 }
 
 // NestedBlocks is the collection of nestedBlocks with the same type
 type NestedBlocks struct {
-	Blocks []*NestedBlock
-	Range  *hcl.Range
+	Blocks  []*NestedBlock
+	Range   *hcl.Range
+	profile *ArgOrderProfile
 }
 
-// CheckOrder checks whether this type of nestedBlocks are sorted
+// CheckOrder checks whether this type of nestedBlocks are sorted, any order is accepted without profile
 func (b *NestedBlocks) CheckOrder() bool {
-	return true
+	if b == nil || b.profile == nil {
+		return true
+	}
+	return slices.IsSortedFunc(b.Blocks, func(x, y *NestedBlock) int {
+		return b.profile.Compare(x.Name, y.Name)
+	})
 }
 
 // ToString prints this type of nestedBlocks in order
@@ -96,9 +103,14 @@ func (b *NestedBlocks) ToString() string {
 	sort.Slice(sortedBlocks, func(i, j int) bool {
 		return sortedBlocks[i].Range.Start.Line < sortedBlocks[j].Range.Start.Line
 	})
+	if b.profile != nil {
+		slices.SortStableFunc(sortedBlocks, func(x, y *NestedBlock) int {
+			return b.profile.Compare(x.Name, y.Name)
+		})
+	}
 	var lines []string
 	for _, nb := range sortedBlocks {
-		lines = append(lines, nb.ToString())
+		lines = append(lines, withComments(nb.File, nb.Range, nb.ToString()))
 	}
 	return string(hclwrite.Format([]byte(strings.Join(lines, "\n"))))
 }
@@ -133,7 +145,7 @@ func (b *NestedBlock) buildAttributes(attributes hclsyntax.Attributes) {
 	for _, attr := range attrs {
 		attrName := attr.Name
 		arg := buildAttrArg(attr, b.File)
-		if IsHeadMeta(attrName) {
+		if b.layout.IsHeadMeta(attrName) {
 			b.addHeadMeta(arg)
 			continue
 		}
@@ -171,6 +183,7 @@ func (b *NestedBlock) buildNestedBlock(nestedBlock *hclsyntax.Block) {
 		ParentBlockNames: parentBlockNames,
 		File:             b.File,
 		emit:             b.emit,
+		layout:           b.layout,
 	}
 	nb.buildAttributes(nestedBlock.Body.Attributes)
 	nb.buildNestedBlocks(nestedBlock.Body.Blocks)
@@ -179,7 +192,7 @@ func (b *NestedBlock) buildNestedBlock(nestedBlock *hclsyntax.Block) {
 
 func (b *NestedBlock) addHeadMeta(arg *Arg) {
 	if b.HeadMetaArgs == nil {
-		b.HeadMetaArgs = &HeadMetaArgs{}
+		b.HeadMetaArgs = &HeadMetaArgs{priority: b.layout.HeadMetaArgPriority}
 	}
 	b.HeadMetaArgs.add(arg)
 }
@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"sort"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// presets are the rules enabled by each preset, with the severity they are reported with
+var presets = map[string]map[string]tflint.Severity{
+	"azure-verified-modules": {
+		"terraform_output_separate":                tflint.ERROR,
+		"terraform_required_providers_declaration": tflint.ERROR,
+		"terraform_required_version_declaration":   tflint.ERROR,
+		"terraform_standard_module_structure":      tflint.ERROR,
+		"terraform_variable_description_required":  tflint.ERROR,
+		"terraform_variable_separate":              tflint.ERROR,
+		"terraform_variable_type_required":         tflint.ERROR,
+		"terraform_versions_file":                  tflint.ERROR,
+	},
+	"recommended": {
+		"terraform_count_vs_for_each":              tflint.NOTICE,
+		"terraform_depends_on_usage":               tflint.WARNING,
+		"terraform_module_source_pinning":          tflint.WARNING,
+		"terraform_required_providers_declaration": tflint.NOTICE,
+		"terraform_required_version_declaration":   tflint.NOTICE,
+		"terraform_unused_declarations":            tflint.WARNING,
+		"terraform_variable_description_required":  tflint.NOTICE,
+		"terraform_variable_type_required":         tflint.WARNING,
+	},
+	"security": {
+		"terraform_heredoc_usage":                     tflint.WARNING,
+		"terraform_module_source_pinning":             tflint.ERROR,
+		"terraform_provider_version_constraint_style": tflint.WARNING,
+		"terraform_required_providers_completeness":   tflint.WARNING,
+		"terraform_sensitive_variable_no_default":     tflint.ERROR,
+	},
+	"strict-layout": {
+		"terraform_lifecycle_block_layout":    tflint.ERROR,
+		"terraform_locals_order":              tflint.ERROR,
+		"terraform_output_order":              tflint.ERROR,
+		"terraform_output_separate":           tflint.ERROR,
+		"terraform_resource_data_arg_layout":  tflint.ERROR,
+		"terraform_standard_module_structure": tflint.ERROR,
+		"terraform_variable_order":            tflint.ERROR,
+		"terraform_variable_separate":         tflint.ERROR,
+		"terraform_versions_file":             tflint.ERROR,
+	},
+}
+
+func presetNames() []string {
+	var names []string
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
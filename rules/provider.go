@@ -7,17 +7,29 @@ import (
 // Rules is a list of all rules
 var Rules = []tflint.Rule{
 	NewTerraformCountIndexUsageRule(),
+	NewTerraformCountVsForEachRule(),
+	NewTerraformDependsOnUsageRule(),
+	NewTerraformDeprecatedArgumentUsageRule(),
 	NewTerraformHeredocUsageRule(),
+	NewTerraformLifecycleBlockLayoutRule(),
 	NewTerraformLocalsOrderRule(),
 	NewTerraformModuleProviderDeclarationRule(),
+	NewTerraformModuleSourcePinningRule(),
 	NewTerraformOutputOrderRule(),
 	NewTerraformOutputSeparateRule(),
+	NewTerraformProviderVersionConstraintStyleRule(),
+	NewTerraformRequiredProvidersCompletenessRule(),
 	NewTerraformRequiredProvidersDeclarationRule(),
 	NewTerraformRequiredVersionDeclarationRule(),
 	NewTerraformResourceDataArgLayoutRule(),
 	NewTerraformSensitiveVariableNoDefaultRule(),
+	NewTerraformStandardModuleStructureRule(),
+	NewTerraformUnusedDeclarationsRule(),
+	NewTerraformUnusedSuppressionsRule(),
+	NewTerraformVariableDescriptionRequiredRule(),
 	NewTerraformVariableNullableFalseRule(),
 	NewTerraformVariableOrderRule(),
 	NewTerraformVariableSeparateRule(),
+	NewTerraformVariableTypeRequiredRule(),
 	NewTerraformVersionsFileRule(),
 }
@@ -0,0 +1,37 @@
+package rules
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/providerschema"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var providerSchemaCache = struct {
+	sync.Mutex
+	schemas map[string]*providerschema.ProviderSchemas
+}{schemas: make(map[string]*providerschema.ProviderSchemas)}
+
+// LoadProviderSchemas loads the local file produced by `terraform providers schema -json`,
+// relative path is resolved against the original working directory, and the loaded schemas are shared across rules
+func LoadProviderSchemas(runner tflint.Runner, path string) (*providerschema.ProviderSchemas, error) {
+	if !filepath.IsAbs(path) {
+		wd, err := runner.GetOriginalwd()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(wd, path)
+	}
+	providerSchemaCache.Lock()
+	defer providerSchemaCache.Unlock()
+	if schemas, ok := providerSchemaCache.schemas[path]; ok {
+		return schemas, nil
+	}
+	schemas, err := providerschema.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	providerSchemaCache.schemas[path] = schemas
+	return schemas, nil
+}
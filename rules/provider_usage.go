@@ -0,0 +1,71 @@
+package rules
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// providerUsages returns the local names of the providers used in the files along with the range of their first usage.
+// Providers are inferred from `provider` blocks, `resource`/`data` blocks (the `provider` meta argument or the resource type prefix)
+// and the values of the `providers` meta argument of `module` blocks
+func providerUsages(files map[string]*hcl.File) map[string]hcl.Range {
+	var filenames []string
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	usages := make(map[string]hcl.Range)
+	use := func(name string, rng hcl.Range) {
+		// resources like `terraform_data` are served by the built-in provider
+		if name == "" || name == "terraform" {
+			return
+		}
+		if _, ok := usages[name]; !ok {
+			usages[name] = rng
+		}
+	}
+	for _, filename := range filenames {
+		body, ok := files[filename].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			switch block.Type {
+			case "provider":
+				use(block.Labels[0], block.DefRange())
+			case "resource", "data":
+				if attr, ok := block.Body.Attributes["provider"]; ok {
+					use(providerRefName(attr.Expr), attr.Expr.Range())
+					continue
+				}
+				use(strings.SplitN(block.Labels[0], "_", 2)[0], block.DefRange())
+			case "module":
+				attr, ok := block.Body.Attributes["providers"]
+				if !ok {
+					continue
+				}
+				providers, ok := attr.Expr.(*hclsyntax.ObjectConsExpr)
+				if !ok {
+					continue
+				}
+				for _, item := range providers.Items {
+					use(providerRefName(item.ValueExpr), item.ValueExpr.Range())
+				}
+			}
+		}
+	}
+	return usages
+}
+
+// providerRefName returns the local name of a provider reference like `azurerm` or `azurerm.west`
+func providerRefName(expr hcl.Expression) string {
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() {
+		return ""
+	}
+	return traversal.RootName()
+}
@@ -1,7 +1,6 @@
 package rules
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -20,19 +19,31 @@ type Block interface {
 
 	// DefRange gets the definition range of the block
 	DefRange() hcl.Range
+
+	// GetRange gets the entire range of the block
+	GetRange() hcl.Range
 }
 
 // ResourceBlock is the wrapper of a resource block
 type ResourceBlock struct {
-	File                 *hcl.File
-	Block                *hclsyntax.Block
-	HeadMetaArgs         *HeadMetaArgs
-	Args                 *Args
-	NestedBlocks         *NestedBlocks
-	TailMetaArgs         *Args
-	TailMetaNestedBlocks *NestedBlocks
-	ParentBlockNames     []string
-	emit                 func(block Block) error
+	File             *hcl.File
+	Block            *hclsyntax.Block
+	HeadMetaArgs     *HeadMetaArgs
+	Args             *Args
+	NestedBlocks     *NestedBlocks
+	TailMetaArgs     *TailMetaArgs
+	ParentBlockNames []string
+	emit             func(block Block) error
+	layout           *MetaArgLayout
+	profile          *ArgOrderProfile
+}
+
+// ResourceBlockOptions customizes the layout expected by the resource block wrapper
+type ResourceBlockOptions struct {
+	// Layout declares the head/tail meta args, DefaultMetaArgLayout is used if nil
+	Layout *MetaArgLayout
+	// ArgOrderProfiles declares the expected order of args, any order is accepted if nil
+	ArgOrderProfiles ArgOrderProfiles
 }
 
 // CheckBlock checks the resource block and nested block recursively to find the block not in order,
@@ -57,14 +68,31 @@ func (b *ResourceBlock) DefRange() hcl.Range {
 	return b.Block.DefRange()
 }
 
+// GetRange gets the entire range of the resource block
+func (b *ResourceBlock) GetRange() hcl.Range {
+	return b.Block.Range()
+}
+
 // BuildResourceBlock Build the root block wrapper using hclsyntax.Block
 func BuildResourceBlock(block *hclsyntax.Block, file *hcl.File,
 	emitter func(block Block) error) *ResourceBlock {
+	return BuildResourceBlockWithOptions(block, file, ResourceBlockOptions{}, emitter)
+}
+
+// BuildResourceBlockWithOptions Build the root block wrapper using hclsyntax.Block with the given options
+func BuildResourceBlockWithOptions(block *hclsyntax.Block, file *hcl.File, options ResourceBlockOptions,
+	emitter func(block Block) error) *ResourceBlock {
+	layout := options.Layout
+	if layout == nil {
+		layout = DefaultMetaArgLayout
+	}
 	b := &ResourceBlock{
 		File:             file,
 		Block:            block,
 		ParentBlockNames: []string{block.Type, block.Labels[0]},
 		emit:             emitter,
+		layout:           layout,
+		profile:          options.ArgOrderProfiles.Profile(block),
 	}
 	b.buildArgs(block.Body.Attributes)
 	b.buildNestedBlocks(block.Body.Blocks)
@@ -81,21 +109,15 @@ func (b *ResourceBlock) ToString() string {
 	headMetaTxt := toString(b.HeadMetaArgs)
 	argTxt := toString(b.Args)
 	nbTxt := toString(b.NestedBlocks)
-	tailMetaArgTxt := toString(b.TailMetaArgs)
-	tailMetaNbTxt := toString(b.TailMetaNestedBlocks)
+	tailMetaTxt := toString(b.TailMetaArgs)
+	headDanglingTxt, danglingTxt := danglingComments(b.File, b.Block)
 	var txts []string
-	for _, subTxt := range []string{headMetaTxt, argTxt, nbTxt, tailMetaArgTxt, tailMetaNbTxt} {
+	for _, subTxt := range []string{headDanglingTxt, headMetaTxt, argTxt, nbTxt, tailMetaTxt, danglingTxt} {
 		if subTxt != "" {
 			txts = append(txts, subTxt)
 		}
 	}
-	txt := strings.Join(txts, "\n\n")
-	blockHead := string(b.Block.DefRange().SliceBytes(b.File.Bytes))
-	if strings.TrimSpace(txt) == "" {
-		txt = fmt.Sprintf("%s {}", blockHead)
-	} else {
-		txt = fmt.Sprintf("%s {\n%s\n}", blockHead, txt)
-	}
+	txt := blockText(b.File, b.Block, strings.Join(txts, "\n\n"))
 	return string(hclwrite.Format([]byte(txt)))
 }
 
@@ -104,11 +126,11 @@ func (b *ResourceBlock) buildArgs(attributes hclsyntax.Attributes) {
 	for _, attr := range attrs {
 		attrName := attr.Name
 		arg := buildAttrArg(attr, b.File)
-		if IsHeadMeta(attrName) {
+		if b.layout.IsHeadMeta(attrName) {
 			b.addHeadMetaArg(arg)
 			continue
 		}
-		if IsTailMeta(attrName) {
+		if b.layout.IsTailMeta(attrName) {
 			b.addTailMetaArg(arg)
 			continue
 		}
@@ -135,6 +157,7 @@ func (b *ResourceBlock) buildNestedBlock(nestedBlock *hclsyntax.Block) *NestedBl
 		ParentBlockNames: parentBlockNames,
 		File:             b.File,
 		emit:             b.emit,
+		layout:           b.layout,
 	}
 	nb.buildAttributes(nestedBlock.Body.Attributes)
 	nb.buildNestedBlocks(nestedBlock.Body.Blocks)
@@ -144,7 +167,7 @@ func (b *ResourceBlock) buildNestedBlock(nestedBlock *hclsyntax.Block) *NestedBl
 func (b *ResourceBlock) buildNestedBlocks(nestedBlocks hclsyntax.Blocks) {
 	for _, nestedBlock := range nestedBlocks {
 		nb := b.buildNestedBlock(nestedBlock)
-		if IsTailMeta(nb.Name) {
+		if b.layout.IsTailMeta(nb.Name) {
 			b.addTailMetaNestedBlock(nb)
 			continue
 		}
@@ -158,7 +181,6 @@ func (b *ResourceBlock) sectionsSorted() bool {
 		b.Args,
 		b.NestedBlocks,
 		b.TailMetaArgs,
-		b.TailMetaNestedBlocks,
 	}
 	lastEndLine := -1
 	for _, s := range sections {
@@ -183,7 +205,6 @@ func (b *ResourceBlock) gaped() bool {
 		b.Args.GetRange(),
 		b.NestedBlocks.GetRange(),
 		b.TailMetaArgs.GetRange(),
-		b.TailMetaNestedBlocks.GetRange(),
 	}
 	lastEndLine := -2
 	for _, r := range ranges {
@@ -200,35 +221,35 @@ func (b *ResourceBlock) gaped() bool {
 
 func (b *ResourceBlock) addHeadMetaArg(arg *Arg) {
 	if b.HeadMetaArgs == nil {
-		b.HeadMetaArgs = &HeadMetaArgs{}
+		b.HeadMetaArgs = &HeadMetaArgs{priority: b.layout.HeadMetaArgPriority}
 	}
 	b.HeadMetaArgs.add(arg)
 }
 
 func (b *ResourceBlock) addTailMetaArg(arg *Arg) {
 	if b.TailMetaArgs == nil {
-		b.TailMetaArgs = &Args{}
+		b.TailMetaArgs = &TailMetaArgs{priority: b.layout.TailMetaArgPriority}
 	}
-	b.TailMetaArgs.add(arg)
+	b.TailMetaArgs.addArg(arg)
 }
 
 func (b *ResourceBlock) addTailMetaNestedBlock(nb *NestedBlock) {
-	if b.TailMetaNestedBlocks == nil {
-		b.TailMetaNestedBlocks = &NestedBlocks{}
+	if b.TailMetaArgs == nil {
+		b.TailMetaArgs = &TailMetaArgs{priority: b.layout.TailMetaArgPriority}
 	}
-	b.TailMetaNestedBlocks.add(nb)
+	b.TailMetaArgs.addBlock(nb)
 }
 
 func (b *ResourceBlock) addArgs(arg *Arg) {
 	if b.Args == nil {
-		b.Args = &Args{}
+		b.Args = &Args{profile: b.profile}
 	}
 	b.Args.add(arg)
 }
 
 func (b *ResourceBlock) addNestedBlock(nb *NestedBlock) {
 	if b.NestedBlocks == nil {
-		b.NestedBlocks = &NestedBlocks{}
+		b.NestedBlocks = &NestedBlocks{profile: b.profile}
 	}
 	b.NestedBlocks.add(nb)
 }
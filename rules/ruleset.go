@@ -0,0 +1,174 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.RuleSet = &RuleSet{}
+
+// RuleSet is the basic-ext ruleset, it extends the builtin ruleset with the options of the `plugin "basic-ext"` block
+type RuleSet struct {
+	tflint.BuiltinRuleSet
+
+	globalConfig *tflint.Config
+}
+
+// Config is the config of the `plugin "basic-ext"` block
+type Config struct {
+	Preset       string            `hclext:"preset,optional"`
+	FileNames    map[string]string `hclext:"file_names,optional"`
+	IncludeFiles []string          `hclext:"include_files,optional"`
+	ExcludeFiles []string          `hclext:"exclude_files,optional"`
+}
+
+// defaultFileNames are the conventional file names of a module, keyed by their role
+var defaultFileNames = map[string]string{
+	"locals":    "locals.tf",
+	"main":      "main.tf",
+	"outputs":   "outputs.tf",
+	"providers": "providers.tf",
+	"variables": "variables.tf",
+	"versions":  "versions.tf",
+}
+
+// pluginConfig is the applied plugin config, shared by all rules
+var pluginConfig = &Config{}
+
+// NewRuleSet returns the basic-ext ruleset serving the given rules, the options shared by all rules are applied to them
+func NewRuleSet(name, version string, rules []tflint.Rule) *RuleSet {
+	var configurableRules []tflint.Rule
+	for _, rule := range rules {
+		configurableRules = append(configurableRules, newConfigurableRule(rule, rule.Severity()))
+	}
+	return &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Name:    name,
+			Version: version,
+			Rules:   configurableRules,
+		},
+	}
+}
+
+// ApplyGlobalConfig enables the rules following the common config and keeps it to apply the preset later
+func (r *RuleSet) ApplyGlobalConfig(config *tflint.Config) error {
+	r.globalConfig = config
+	return r.BuiltinRuleSet.ApplyGlobalConfig(config)
+}
+
+// ConfigSchema returns the schema of the `plugin "basic-ext"` block
+func (r *RuleSet) ConfigSchema() *hclext.BodySchema {
+	return hclext.ImpliedBodySchema(&Config{})
+}
+
+// ApplyConfig decodes the `plugin "basic-ext"` block and enables the rules of the selected preset
+func (r *RuleSet) ApplyConfig(content *hclext.BodyContent) error {
+	config := &Config{}
+	if diags := hclext.DecodeBody(content, nil, config); diags.HasErrors() {
+		return diags
+	}
+	if err := config.validate(); err != nil {
+		return err
+	}
+	pluginConfig = config
+	if config.Preset == "" || r.globalConfig == nil {
+		return nil
+	}
+	preset := presets[config.Preset]
+	r.EnabledRules = []tflint.Rule{}
+	for _, rule := range r.Rules {
+		_, inPreset := preset[rule.Name()]
+		if !r.enabled(rule, inPreset) {
+			continue
+		}
+		if inPreset {
+			rule = newConfigurableRule(rule, preset[rule.Name()])
+		}
+		r.EnabledRules = append(r.EnabledRules, rule)
+	}
+	return nil
+}
+
+// enabled checks whether the rule is enabled, the `--only` option and the `rule` blocks take precedence over the preset
+func (r *RuleSet) enabled(rule tflint.Rule, inPreset bool) bool {
+	if len(r.globalConfig.Only) > 0 {
+		return slices.Contains(r.globalConfig.Only, rule.Name())
+	}
+	if cfg := r.globalConfig.Rules[rule.Name()]; cfg != nil {
+		return cfg.Enabled
+	}
+	return inPreset || rule.Enabled() && !r.globalConfig.DisabledByDefault
+}
+
+func (c *Config) validate() error {
+	if _, ok := presets[c.Preset]; c.Preset != "" && !ok {
+		return fmt.Errorf("unknown preset `%s`, available presets: %s", c.Preset, strings.Join(presetNames(), ", "))
+	}
+	for role := range c.FileNames {
+		if _, ok := defaultFileNames[role]; !ok {
+			return fmt.Errorf("unknown file role `%s` in `file_names`", role)
+		}
+	}
+	for _, pattern := range slices.Concat(c.IncludeFiles, c.ExcludeFiles) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid file pattern `%s`: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// fileName returns the file name of the role, e.g. `variables.tf` for `variables`, following the `file_names` option
+func fileName(role string) string {
+	if name, ok := pluginConfig.FileNames[role]; ok {
+		return name
+	}
+	return defaultFileNames[role]
+}
+
+// isLintedFile checks whether the file is matched by `include_files` if set, and not matched by `exclude_files`
+func isLintedFile(filename string) bool {
+	if len(pluginConfig.IncludeFiles) > 0 && !matchFile(pluginConfig.IncludeFiles, filename) {
+		logger.Debug(fmt.Sprintf("skip %s since it's not included", filename))
+		return false
+	}
+	if matchFile(pluginConfig.ExcludeFiles, filename) {
+		logger.Debug(fmt.Sprintf("skip %s since it's excluded", filename))
+		return false
+	}
+	return true
+}
+
+// matchFile checks whether the file path or the base name of the file matches any of the patterns
+func matchFile(patterns []string, filename string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, filename); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, filepath.Base(filename)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// lintedFiles returns the files of the module which are not filtered out by `include_files` and `exclude_files`
+func lintedFiles(runner tflint.Runner) (map[string]*hcl.File, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+	linted := make(map[string]*hcl.File)
+	for filename, file := range files {
+		if isLintedFile(filename) {
+			linted[filename] = file
+		}
+	}
+	return linted, nil
+}
@@ -0,0 +1,263 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func applyPluginConfig(t *testing.T, ruleSet *RuleSet, config string) error {
+	t.Cleanup(func() {
+		pluginConfig = &Config{}
+	})
+	file, diags := hclsyntax.ParseConfig([]byte(config), "plugin.hcl", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+	content, diags := hclext.Content(file.Body, ruleSet.ConfigSchema())
+	require.False(t, diags.HasErrors(), diags.Error())
+	return ruleSet.ApplyConfig(content)
+}
+
+func enabledRuleNames(ruleSet *RuleSet) []string {
+	var names []string
+	for _, rule := range ruleSet.EnabledRules {
+		names = append(names, rule.Name())
+	}
+	return names
+}
+
+func Test_RuleSet_Preset(t *testing.T) {
+	ruleSet := NewRuleSet("basic-ext", "0.0.1", Rules)
+	require.NoError(t, ruleSet.ApplyGlobalConfig(&tflint.Config{
+		Rules: map[string]*tflint.RuleConfig{
+			"terraform_versions_file":  {Name: "terraform_versions_file", Enabled: false},
+			"terraform_variable_order": {Name: "terraform_variable_order", Enabled: true},
+		},
+	}))
+	require.NoError(t, applyPluginConfig(t, ruleSet, `preset = "azure-verified-modules"`))
+	assert.Equal(t, []string{
+		"terraform_output_separate",
+		"terraform_required_providers_declaration",
+		"terraform_required_version_declaration",
+		"terraform_standard_module_structure",
+		"terraform_variable_description_required",
+		"terraform_variable_nullable_false",
+		"terraform_variable_order",
+		"terraform_variable_separate",
+		"terraform_variable_type_required",
+	}, enabledRuleNames(ruleSet))
+}
+
+func Test_RuleSet_PresetSeverity(t *testing.T) {
+	ruleSet := NewRuleSet("basic-ext", "0.0.1", Rules)
+	require.NoError(t, ruleSet.ApplyGlobalConfig(&tflint.Config{
+		Rules: map[string]*tflint.RuleConfig{
+			"terraform_locals_order": {Name: "terraform_locals_order", Enabled: false},
+		},
+	}))
+	require.NoError(t, applyPluginConfig(t, ruleSet, `preset = "strict-layout"`))
+	names := enabledRuleNames(ruleSet)
+	assert.NotContains(t, names, "terraform_locals_order")
+	assert.Contains(t, names, "terraform_variable_nullable_false")
+
+	var variableOrderRule tflint.Rule
+	for _, rule := range ruleSet.EnabledRules {
+		if rule.Name() == "terraform_variable_order" {
+			variableOrderRule = rule
+		}
+	}
+	require.NotNil(t, variableOrderRule)
+	runner := helper.TestRunner(t, map[string]string{"variables.tf": `
+variable "b" {}
+
+variable "a" {}`})
+	require.NoError(t, variableOrderRule.Check(runner))
+	require.Len(t, runner.Issues, 1)
+	assert.Equal(t, tflint.ERROR, runner.Issues[0].Rule.Severity())
+	assert.Equal(t, "terraform_variable_order", runner.Issues[0].Rule.Name())
+}
+
+func Test_Presets(t *testing.T) {
+	ruleNames := make(map[string]bool)
+	for _, rule := range Rules {
+		ruleNames[rule.Name()] = true
+	}
+	for preset, rules := range presets {
+		for name := range rules {
+			assert.True(t, ruleNames[name], "rule `%s` of preset `%s` doesn't exist", name, preset)
+		}
+	}
+}
+
+func Test_Presets_EmptyModule(t *testing.T) {
+	for _, preset := range presetNames() {
+		t.Run(preset, func(t *testing.T) {
+			ruleSet := NewRuleSet("basic-ext", "0.0.1", Rules)
+			require.NoError(t, ruleSet.ApplyGlobalConfig(&tflint.Config{DisabledByDefault: true}))
+			require.NoError(t, applyPluginConfig(t, ruleSet, fmt.Sprintf("preset = %q", preset)))
+			require.Len(t, ruleSet.EnabledRules, len(presets[preset]))
+			for _, rule := range ruleSet.EnabledRules {
+				runner := helper.TestRunner(t, map[string]string{"main.tf": ""})
+				assert.NoError(t, rule.Check(runner), "rule `%s` of preset `%s` fails on an empty module", rule.Name(), preset)
+			}
+		})
+	}
+}
+
+func Test_RuleSet_InvalidConfig(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected string
+	}{
+		{
+			Name:     "unknown preset",
+			Config:   `preset = "unknown"`,
+			Expected: "unknown preset `unknown`, available presets: azure-verified-modules, recommended, security, strict-layout",
+		},
+		{
+			Name:     "unknown file role",
+			Config:   `file_names = { vars = "vars.tf" }`,
+			Expected: "unknown file role `vars` in `file_names`",
+		},
+		{
+			Name:     "invalid pattern",
+			Config:   `exclude_files = ["[.tf"]`,
+			Expected: "invalid file pattern `[.tf`: syntax error in pattern",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ruleSet := NewRuleSet("basic-ext", "0.0.1", Rules)
+			require.NoError(t, ruleSet.ApplyGlobalConfig(&tflint.Config{}))
+			assert.EqualError(t, applyPluginConfig(t, ruleSet, tc.Config), tc.Expected)
+		})
+	}
+}
+
+func Test_RuleSet_FileOptions(t *testing.T) {
+	ruleSet := NewRuleSet("basic-ext", "0.0.1", Rules)
+	require.NoError(t, ruleSet.ApplyGlobalConfig(&tflint.Config{}))
+	require.NoError(t, applyPluginConfig(t, ruleSet, `
+file_names    = { versions = "terraform.tf" }
+exclude_files = ["generated_*.tf"]
+`))
+	runner := helper.TestRunner(t, map[string]string{
+		"terraform.tf": `
+terraform {}
+
+locals {}`,
+		"versions.tf": `
+locals {}`,
+		"generated_variables.tf": `
+variable "b" {}
+
+variable "a" {}`,
+	})
+	require.NoError(t, NewTerraformVersionsFileRule().Check(runner))
+	require.NoError(t, NewTerraformVariableOrderRule().Check(runner))
+	AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformVersionsFileRule(),
+			Message: "`terraform.tf` should have and only have 1 `terraform` block",
+		},
+	}, runner.Issues)
+}
+
+func Test_RuleSet_ExcludedFile(t *testing.T) {
+	schemaFile := filepath.Join(t.TempDir(), "provider_schema.json")
+	require.NoError(t, os.WriteFile(schemaFile, []byte(testProviderSchema), 0600))
+	files := map[string]string{
+		".tflint.hcl": fmt.Sprintf(`
+rule "terraform_deprecated_argument_usage" {
+  enabled              = true
+  provider_schema_file = %q
+}`, schemaFile),
+		"main.tf": `
+resource "azurerm_resource_group" "other" {
+  name     = "other"
+  location = "westeurope"
+}`,
+		"excluded.tf": `
+terraform {
+  required_providers {
+    random  = { source = "hashicorp/random", version = "3.0" }
+    azurerm = { version = ">= 3.0", source = "hashicorp/azurerm" }
+  }
+}
+
+locals {
+  b = 1
+  a = <<EOT
+{"a": 1}
+EOT
+}
+
+variable "b" {
+  default   = "b"
+  nullable  = true
+  sensitive = true
+}
+variable "a" {
+  type = any
+}
+
+output "o" {
+  value = azurerm_resource_group.rg[count.index].name
+}
+
+module "m" {
+  source    = "git::https://example.com/m.git"
+  providers = { azurerm = azurerm }
+}
+
+resource "azurerm_resource_group" "rg" {
+  count = 1
+  lifecycle {}
+  name       = "rg"
+  depends_on = [azurerm_resource_group.other]
+}
+
+resource "azurerm_kubernetes_cluster" "this" {
+  name                            = "aks"
+  api_server_authorized_ip_ranges = []
+}
+
+# basic-ext:ignore terraform_unknown_rule
+resource "null_resource" "n" {}`,
+	}
+	issuesInExcludedFile := func(t *testing.T, rule tflint.Rule) int {
+		runner := helper.TestRunner(t, files)
+		require.NoError(t, rule.Check(runner), "rule `%s` fails", rule.Name())
+		count := 0
+		for _, issue := range runner.Issues {
+			if issue.Range.Filename == "excluded.tf" {
+				count++
+			}
+		}
+		return count
+	}
+
+	var reported []string
+	for _, rule := range Rules {
+		if issuesInExcludedFile(t, rule) > 0 {
+			reported = append(reported, rule.Name())
+		}
+	}
+	assert.Subset(t, reported, []string{"terraform_variable_nullable_false", "terraform_variable_type_required"})
+
+	ruleSet := NewRuleSet("basic-ext", "0.0.1", Rules)
+	require.NoError(t, ruleSet.ApplyGlobalConfig(&tflint.Config{}))
+	require.NoError(t, applyPluginConfig(t, ruleSet, `exclude_files = ["excluded.tf"]`))
+	for _, rule := range Rules {
+		assert.Zero(t, issuesInExcludedFile(t, rule), "rule `%s` reports issues in the excluded file", rule.Name())
+	}
+}
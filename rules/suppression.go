@@ -0,0 +1,87 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const (
+	ignoreCommentPrefix     = "basic-ext:ignore"
+	ignoreFileCommentPrefix = "basic-ext:ignore-file"
+)
+
+// suppression is a rule suppressed by a comment, either in the whole file with `# basic-ext:ignore-file <rules>`,
+// or in the block following the comment with `# basic-ext:ignore <rules>`
+type suppression struct {
+	Rule    string
+	Comment hcl.Range
+	// File is true if the suppression covers the whole file
+	File bool
+	// Scope is the range of the block following the comment, it's empty if the comment isn't followed by a block
+	Scope hcl.Range
+}
+
+// covers checks whether the issue at the location is suppressed
+func (s suppression) covers(location hcl.Range) bool {
+	if location.Filename != s.Comment.Filename {
+		return false
+	}
+	if s.File {
+		return true
+	}
+	return s.Scope.Filename != "" && s.Scope.ContainsOffset(location.Start.Byte)
+}
+
+// collectSuppressions parses the suppression comments from the tokens of the file
+func collectSuppressions(file *hcl.File) []suppression {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+	tokens, _ := hclsyntax.LexConfig(file.Bytes, body.Range().Filename, hcl.InitialPos)
+	var suppressions []suppression
+	for _, token := range tokens {
+		if token.Type != hclsyntax.TokenComment {
+			continue
+		}
+		text := strings.TrimSpace(string(token.Bytes))
+		if strings.HasPrefix(text, "/*") {
+			continue
+		}
+		text = strings.TrimSpace(strings.TrimLeft(text, "#/"))
+		fileScoped := strings.HasPrefix(text, ignoreFileCommentPrefix+" ")
+		if !fileScoped && !strings.HasPrefix(text, ignoreCommentPrefix+" ") {
+			continue
+		}
+		var scope hcl.Range
+		if !fileScoped {
+			scope = blockRangeAtLine(body, token.Range.Start.Line+1)
+		}
+		names := strings.FieldsFunc(text[strings.Index(text, " "):], func(r rune) bool {
+			return r == ' ' || r == ','
+		})
+		for _, name := range names {
+			suppressions = append(suppressions, suppression{
+				Rule:    name,
+				Comment: token.Range,
+				File:    fileScoped,
+				Scope:   scope,
+			})
+		}
+	}
+	return suppressions
+}
+
+// blockRangeAtLine returns the range of the outermost block starting at the line
+func blockRangeAtLine(body *hclsyntax.Body, line int) hcl.Range {
+	var rng hcl.Range
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		if block, ok := node.(*hclsyntax.Block); ok && rng.Filename == "" && block.Range().Start.Line == line {
+			rng = block.Range()
+		}
+		return nil
+	})
+	return rng
+}
@@ -0,0 +1,57 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Suppression(t *testing.T) {
+	rule := newConfigurableRule(NewTerraformVariableTypeRequiredRule(), NewTerraformVariableTypeRequiredRule().Severity())
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. file-scoped suppression",
+			Content: `
+# basic-ext:ignore-file terraform_variable_description_required, terraform_variable_type_required
+variable "image_id" {}
+
+variable "zone" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. block-scoped suppression",
+			Content: `
+// basic-ext:ignore terraform_variable_type_required
+variable "image_id" {}
+
+# basic-ext:ignore terraform_variable_type_required
+
+variable "zone" {}
+
+# basic-ext:ignore terraform_variable_description_required
+variable "region" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "`type` is required for variable `zone`",
+				},
+				{
+					Rule:    rule,
+					Message: "`type` is required for variable `region`",
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+			require.NoError(t, rule.Check(runner))
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
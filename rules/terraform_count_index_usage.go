@@ -1,10 +1,13 @@
 package rules
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
-	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
@@ -35,76 +38,125 @@ func (r *TerraformCountIndexUsageRule) Severity() tflint.Severity {
 }
 
 func (r *TerraformCountIndexUsageRule) Check(runner tflint.Runner) error {
-	return ForFiles(runner, r.CheckFile)
+	return r.walker().Walk(runner)
 }
 
 func (r *TerraformCountIndexUsageRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
-	body, ok := file.Body.(*hclsyntax.Body)
-	if !ok {
-		logger.Debug("skip terraform_count_index_usage since it's not hcl file")
-		return nil
+	return r.walker().WalkFile(runner, file)
+}
+
+func (r *TerraformCountIndexUsageRule) walker() *Walker {
+	return &Walker{
+		IncludeOverrideFiles: true,
+		Blocks: []BlockVisitor{{
+			TopLevel: true,
+			Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+				return r.visitBlock(ctx.Runner, ctx.File, block)
+			},
+		}},
 	}
-	blocks := body.Blocks
+}
+
+// subscript is an expression that looks up a list/map with a key, e.g. `x[key]`, `element(x, key)` or `lookup(x, key)`
+type subscript struct {
+	expr       hclsyntax.Expression
+	collection hclsyntax.Expression
+	key        hclsyntax.Expression
+}
+
+func (r *TerraformCountIndexUsageRule) visitBlock(runner tflint.Runner, file *hcl.File, block *hclsyntax.Block) error {
+	var subscripts []subscript
+	var countIndexes []*hclsyntax.ScopeTraversalExpr
+	_ = hclsyntax.VisitAll(block.Body, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch expr := node.(type) {
+		case *hclsyntax.IndexExpr:
+			subscripts = append(subscripts, subscript{expr: expr, collection: expr.Collection, key: expr.Key})
+		case *hclsyntax.FunctionCallExpr:
+			if (expr.Name == "element" || expr.Name == "lookup") && len(expr.Args) >= 2 {
+				subscripts = append(subscripts, subscript{expr: expr, collection: expr.Args[0], key: expr.Args[1]})
+			}
+		case *hclsyntax.ScopeTraversalExpr:
+			if isCountIndex(expr.Traversal) {
+				countIndexes = append(countIndexes, expr)
+			}
+		}
+		return nil
+	})
+	// attributes are visited in map order
+	sort.Slice(countIndexes, func(i, j int) bool {
+		return countIndexes[i].SrcRange.Start.Byte < countIndexes[j].SrcRange.Start.Byte
+	})
 	var err error
-	for _, block := range blocks {
-		if subErr := r.visitBlock(runner, block); subErr != nil {
+	for _, countIndex := range countIndexes {
+		s, ok := r.innermostSubscript(subscripts, countIndex.SrcRange)
+		if !ok {
+			continue
+		}
+		msg := "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead"
+		if hint := r.forEachHint(file, block, s); hint != "" {
+			msg = fmt.Sprintf("%s, %s", msg, hint)
+		}
+		if subErr := runner.EmitIssue(r, msg, countIndex.SrcRange); subErr != nil {
 			err = multierror.Append(err, subErr)
 		}
 	}
 	return err
 }
 
-func (r *TerraformCountIndexUsageRule) visitBlock(runner tflint.Runner, block *hclsyntax.Block) error {
-	var err error
-	for _, attr := range block.Body.Attributes {
-		if subErr := r.visitExp(runner, attr.Expr); subErr != nil {
-			err = multierror.Append(err, subErr)
+// innermostSubscript returns the innermost subscript whose key contains the range
+func (r *TerraformCountIndexUsageRule) innermostSubscript(subscripts []subscript, rng hcl.Range) (subscript, bool) {
+	var innermost subscript
+	found := false
+	for _, s := range subscripts {
+		keyRange := s.key.Range()
+		if !keyRange.ContainsOffset(rng.Start.Byte) {
+			continue
 		}
-	}
-	for _, nestedBlock := range block.Body.Blocks {
-		if subErr := r.visitBlock(runner, nestedBlock); subErr != nil {
-			err = multierror.Append(err, subErr)
+		if !found || keyRange.Start.Byte >= innermost.key.Range().Start.Byte && keyRange.End.Byte <= innermost.key.Range().End.Byte {
+			innermost = s
+			found = true
 		}
 	}
-	return err
+	return innermost, found
 }
 
-func (r *TerraformCountIndexUsageRule) visitExp(runner tflint.Runner, exp hclsyntax.Expression) error {
-	file, _ := runner.GetFile(exp.Range().Filename)
-	tokens, diags := hclsyntax.LexExpression(exp.Range().SliceBytes(file.Bytes),
-		exp.Range().Filename,
-		exp.StartRange().Start)
-	if diags.HasErrors() {
-		return diags
+// forEachHint suggests the `for_each` rewrite of the block if it's declared with `count = length(x)`
+func (r *TerraformCountIndexUsageRule) forEachHint(file *hcl.File, block *hclsyntax.Block, s subscript) string {
+	countAttr, ok := block.Body.Attributes["count"]
+	if !ok {
+		return ""
 	}
-	var err error
-	depth := 0
-	for i, token := range tokens {
-		switch token.Type {
-		case hclsyntax.TokenOBrack:
-			depth++
-		case hclsyntax.TokenCBrack:
-			depth--
-		case hclsyntax.TokenIdent:
-			if depth == 0 || i+2 >= len(tokens) {
-				continue
-			}
-			first, second, third := string(token.Bytes), string(tokens[i+1].Bytes), string(tokens[i+2].Bytes)
-			if first == "count" && second == "." && third == "index" {
-				subErr := runner.EmitIssue(
-					r,
-					"`count.index` is not recommended to be used as the subscript of list/map, use for_each instead",
-					hcl.Range{
-						Filename: token.Range.Filename,
-						Start:    token.Range.Start,
-						End:      tokens[i+2].Range.End,
-					},
-				)
-				if subErr != nil {
-					err = multierror.Append(err, subErr)
-				}
-			}
-		}
+	length, ok := countAttr.Expr.(*hclsyntax.FunctionCallExpr)
+	if !ok || length.Name != "length" || len(length.Args) != 1 {
+		return ""
 	}
-	return err
+	collection := exprText(file, length.Args[0])
+	hint := fmt.Sprintf("consider `for_each = toset(%s)` for `%s`", collection, blockAddress(block))
+	if scopeTraversal, ok := s.key.(*hclsyntax.ScopeTraversalExpr); ok && isCountIndex(scopeTraversal.Traversal) && exprText(file, s.collection) == collection {
+		hint = fmt.Sprintf("%s and `each.value` instead of `%s`", hint, exprText(file, s.expr))
+	}
+	return hint
+}
+
+func isCountIndex(traversal hcl.Traversal) bool {
+	if len(traversal) != 2 || traversal.RootName() != "count" {
+		return false
+	}
+	attr, ok := traversal[1].(hcl.TraverseAttr)
+	return ok && attr.Name == "index"
+}
+
+func exprText(file *hcl.File, expr hclsyntax.Expression) string {
+	return string(expr.Range().SliceBytes(file.Bytes))
+}
+
+// blockAddress returns the address of a top level block, e.g. `azurerm_resource_group.example`, `data.azurerm_client_config.current` or `module.network`
+func blockAddress(block *hclsyntax.Block) string {
+	switch block.Type {
+	case "resource":
+		return strings.Join(block.Labels, ".")
+	case "data":
+		return fmt.Sprintf("data.%s", strings.Join(block.Labels, "."))
+	}
+	return strings.Join(append([]string{block.Type}, block.Labels...), ".")
 }
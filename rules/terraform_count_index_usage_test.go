@@ -1,8 +1,10 @@
 package rules
 
 import (
-	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 )
 
 func Test_TerraformCountIndexUsageRule(t *testing.T) {
@@ -24,7 +26,7 @@ resource "azurerm_resource_group" "default" {
 			Expected: helper.Issues{
 				{
 					Rule:    NewTerraformCountIndexUsageRule(),
-					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead",
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.my_list)` for `azurerm_resource_group.default` and `each.value` instead of `var.my_list[count.index]`",
 				},
 			},
 		},
@@ -40,7 +42,7 @@ resource "azurerm_resource_group" "default" {
 			Expected: helper.Issues{
 				{
 					Rule:    NewTerraformCountIndexUsageRule(),
-					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead",
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.my_list)` for `azurerm_resource_group.default`",
 				},
 			},
 		},
@@ -56,7 +58,7 @@ resource "azurerm_resource_group" "default" {
 			Expected: helper.Issues{
 				{
 					Rule:    NewTerraformCountIndexUsageRule(),
-					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead",
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.my_list)` for `azurerm_resource_group.default` and `each.value` instead of `var.my_list[count.index]`",
 				},
 			},
 		},
@@ -86,15 +88,15 @@ resource "azurerm_resource_group" "default3" {
 			Expected: helper.Issues{
 				{
 					Rule:    NewTerraformCountIndexUsageRule(),
-					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead",
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.my_list)` for `azurerm_resource_group.default1` and `each.value` instead of `var.my_list[count.index]`",
 				},
 				{
 					Rule:    NewTerraformCountIndexUsageRule(),
-					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead",
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.my_list)` for `azurerm_resource_group.default2`",
 				},
 				{
 					Rule:    NewTerraformCountIndexUsageRule(),
-					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead",
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.my_list)` for `azurerm_resource_group.default3` and `each.value` instead of `var.my_list[count.index]`",
 				},
 			},
 		},
@@ -116,6 +118,57 @@ resource "azurerm_resource_group" "default2" {
 }`,
 			Expected: helper.Issues{},
 		},
+		{
+			Name: "6. function calls, for expressions and relative traversals",
+			Content: `
+resource "azurerm_subnet" "default" {
+  count = length(var.subnets)
+
+  name                 = element(var.subnets, count.index)
+  address_prefixes     = [lookup(var.prefixes, count.index, "10.0.0.0/24")]
+  virtual_network_name = [for vnet in var.vnets : vnet.name][count.index]
+  resource_group_name  = azurerm_resource_group.default[count.index].name
+
+  dynamic "delegation" {
+    for_each = var.delegations
+    content {
+      name = [for d in var.delegations : d if d.subnet == var.subnets[count.index]][0]
+    }
+  }
+}
+
+data "azurerm_subnet" "default" {
+  count = 2
+
+  name = var.subnets[count.index]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformCountIndexUsageRule(),
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.subnets)` for `azurerm_subnet.default` and `each.value` instead of `element(var.subnets, count.index)`",
+				},
+				{
+					Rule:    NewTerraformCountIndexUsageRule(),
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.subnets)` for `azurerm_subnet.default`",
+				},
+				{
+					Rule:    NewTerraformCountIndexUsageRule(),
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.subnets)` for `azurerm_subnet.default`",
+				},
+				{
+					Rule:    NewTerraformCountIndexUsageRule(),
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.subnets)` for `azurerm_subnet.default`",
+				},
+				{
+					Rule:    NewTerraformCountIndexUsageRule(),
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead, consider `for_each = toset(var.subnets)` for `azurerm_subnet.default` and `each.value` instead of `var.subnets[count.index]`",
+				},
+				{
+					Rule:    NewTerraformCountIndexUsageRule(),
+					Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead",
+				},
+			},
+		},
 	}
 	rule := NewTerraformCountIndexUsageRule()
 	for _, tc := range cases {
@@ -133,3 +186,25 @@ resource "azurerm_resource_group" "default2" {
 		})
 	}
 }
+
+func Test_TerraformCountIndexUsageRule_OverrideFile(t *testing.T) {
+	rule := NewTerraformCountIndexUsageRule()
+	runner := helper.TestRunner(t, map[string]string{"main_override.tf": `
+resource "azurerm_resource_group" "default" {
+  name = var.my_list[count.index]
+}`})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformCountIndexUsageRule(),
+			Message: "`count.index` is not recommended to be used as the subscript of list/map, use for_each instead",
+			Range: hcl.Range{
+				Filename: "main_override.tf",
+				Start:    hcl.Pos{Line: 3, Column: 22, Byte: 68},
+				End:      hcl.Pos{Line: 3, Column: 33, Byte: 79},
+			},
+		},
+	}, runner.Issues)
+}
@@ -0,0 +1,225 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ tflint.Rule = &TerraformCountVsForEachRule{}
+
+// TerraformCountVsForEachRule checks whether `count` and `for_each` are used for what they fit
+type TerraformCountVsForEachRule struct {
+	tflint.DefaultRule
+}
+
+// listFunctions are the functions returning a list, which can't be used in `for_each` directly
+var listFunctions = map[string]bool{
+	"compact":  true,
+	"concat":   true,
+	"distinct": true,
+	"flatten":  true,
+	"keys":     true,
+	"range":    true,
+	"reverse":  true,
+	"slice":    true,
+	"sort":     true,
+	"split":    true,
+	"values":   true,
+}
+
+// NewTerraformCountVsForEachRule returns a new rule
+func NewTerraformCountVsForEachRule() *TerraformCountVsForEachRule {
+	return &TerraformCountVsForEachRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformCountVsForEachRule) Name() string {
+	return "terraform_count_vs_for_each"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformCountVsForEachRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformCountVsForEachRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformCountVsForEachRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks the `count` and `for_each` of resources, data sources and modules
+func (r *TerraformCountVsForEachRule) Check(runner tflint.Runner) error {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	listVariables := r.listVariables(files)
+	visit := func(ctx *WalkContext, block *hclsyntax.Block) error {
+		return r.checkBlock(ctx.Runner, ctx.File, block, listVariables)
+	}
+	walker := &Walker{
+		Blocks: []BlockVisitor{
+			{Type: "resource", TopLevel: true, Visit: visit},
+			{Type: "data", TopLevel: true, Visit: visit},
+			{Type: "module", TopLevel: true, Visit: visit},
+		},
+	}
+	return walker.Walk(runner)
+}
+
+func (r *TerraformCountVsForEachRule) checkBlock(runner tflint.Runner, file *hcl.File, block *hclsyntax.Block, listVariables map[string]bool) error {
+	var err error
+	for _, attr := range attributesByLines(block.Body.Attributes) {
+		if !IsHeadMeta(attr.Name) {
+			continue
+		}
+		var msg string
+		switch attr.Name {
+		case "count":
+			msg = r.checkCount(file, block, attr.Expr)
+		case "for_each":
+			msg = r.checkForEach(file, block, attr.Expr, listVariables)
+		}
+		if msg == "" {
+			continue
+		}
+		if subErr := runner.EmitIssue(r, msg, attr.Expr.Range()); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+// listVariables returns the names of the variables declared with a list or tuple type
+func (r *TerraformCountVsForEachRule) listVariables(files map[string]*hcl.File) map[string]bool {
+	variables := make(map[string]bool)
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "variable" {
+				continue
+			}
+			attr, ok := block.Body.Attributes["type"]
+			if !ok {
+				continue
+			}
+			if call, ok := attr.Expr.(*hclsyntax.FunctionCallExpr); ok && (call.Name == "list" || call.Name == "tuple") {
+				variables[block.Labels[0]] = true
+			}
+		}
+	}
+	return variables
+}
+
+func (r *TerraformCountVsForEachRule) checkCount(file *hcl.File, block *hclsyntax.Block, expr hclsyntax.Expression) string {
+	address := blockAddress(block)
+	switch e := expr.(type) {
+	case *hclsyntax.FunctionCallExpr:
+		if e.Name == "length" && len(e.Args) == 1 {
+			collection := exprText(file, e.Args[0])
+			return fmt.Sprintf("`%s` uses `count = length(%s)`, use `for_each = toset(%s)` for stable addresses", address, collection, collection)
+		}
+	case *hclsyntax.ConditionalExpr:
+		condition, ok := e.Condition.(*hclsyntax.BinaryOpExpr)
+		if !ok || condition.Op != hclsyntax.OpEqual && condition.Op != hclsyntax.OpNotEqual {
+			return ""
+		}
+		operand, value, ok := r.boolComparison(file, condition)
+		if !ok {
+			return ""
+		}
+		if value != (condition.Op == hclsyntax.OpEqual) {
+			operand = "!" + operand
+		}
+		return fmt.Sprintf("`%s` compares a boolean in `count`, use `count = %s ? %s : %s` instead", address, operand, exprText(file, e.TrueResult), exprText(file, e.FalseResult))
+	}
+	return ""
+}
+
+// boolComparison returns the other operand and the boolean literal of a comparison like `var.enabled == true`
+func (r *TerraformCountVsForEachRule) boolComparison(file *hcl.File, condition *hclsyntax.BinaryOpExpr) (string, bool, bool) {
+	for _, pair := range [][2]hclsyntax.Expression{{condition.LHS, condition.RHS}, {condition.RHS, condition.LHS}} {
+		literal, ok := pair[1].(*hclsyntax.LiteralValueExpr)
+		if !ok || literal.Val.Type() != cty.Bool || literal.Val.IsNull() {
+			continue
+		}
+		return exprText(file, pair[0]), literal.Val.True(), true
+	}
+	return "", false, false
+}
+
+func (r *TerraformCountVsForEachRule) checkForEach(file *hcl.File, block *hclsyntax.Block, expr hclsyntax.Expression, listVariables map[string]bool) string {
+	address := blockAddress(block)
+	if conditional, ok := expr.(*hclsyntax.ConditionalExpr); ok {
+		condition := exprText(file, conditional.Condition)
+		switch {
+		case isSingleElementCollection(conditional.TrueResult) && isEmptyCollection(conditional.FalseResult):
+			return fmt.Sprintf("`%s` is created conditionally with `for_each`, use `count = %s ? 1 : 0` instead", address, condition)
+		case isEmptyCollection(conditional.TrueResult) && isSingleElementCollection(conditional.FalseResult):
+			return fmt.Sprintf("`%s` is created conditionally with `for_each`, use `count = %s ? 0 : 1` instead", address, condition)
+		}
+		return ""
+	}
+	if r.isList(expr, listVariables) {
+		return fmt.Sprintf("`%s` iterates a list with `for_each`, use `for_each = toset(%s)` instead", address, exprText(file, expr))
+	}
+	return ""
+}
+
+// isList checks whether the expression evaluates to a list, e.g. `["a", "b"]`, `[for ...]`, `concat(...)` or a variable of list type
+func (r *TerraformCountVsForEachRule) isList(expr hclsyntax.Expression, listVariables map[string]bool) bool {
+	switch e := expr.(type) {
+	case *hclsyntax.TupleConsExpr:
+		return true
+	case *hclsyntax.ForExpr:
+		return e.KeyExpr == nil
+	case *hclsyntax.FunctionCallExpr:
+		return listFunctions[e.Name]
+	case *hclsyntax.ScopeTraversalExpr:
+		if len(e.Traversal) != 2 || e.Traversal.RootName() != "var" {
+			return false
+		}
+		attr, ok := e.Traversal[1].(hcl.TraverseAttr)
+		return ok && listVariables[attr.Name]
+	}
+	return false
+}
+
+// isEmptyCollection checks whether the expression is `[]`, `{}`, `toset([])` or `tomap({})`
+func isEmptyCollection(expr hclsyntax.Expression) bool {
+	return collectionSize(expr) == 0
+}
+
+// isSingleElementCollection checks whether the expression is a collection literal with exactly one element
+func isSingleElementCollection(expr hclsyntax.Expression) bool {
+	return collectionSize(expr) == 1
+}
+
+// collectionSize returns the number of elements of a collection literal, or -1 if it's not a collection literal
+func collectionSize(expr hclsyntax.Expression) int {
+	switch e := expr.(type) {
+	case *hclsyntax.TupleConsExpr:
+		return len(e.Exprs)
+	case *hclsyntax.ObjectConsExpr:
+		return len(e.Items)
+	case *hclsyntax.FunctionCallExpr:
+		if (e.Name == "toset" || e.Name == "tomap") && len(e.Args) == 1 {
+			return collectionSize(e.Args[0])
+		}
+	}
+	return -1
+}
@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformCountVsForEachRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. proper usages",
+			Content: `
+variable "names" {
+  type = set(string)
+}
+
+resource "azurerm_resource_group" "conditional" {
+  count = var.enabled ? 1 : 0
+
+  name     = "example"
+  location = "westus"
+}
+
+resource "azurerm_resource_group" "set" {
+  for_each = toset(var.list)
+
+  name     = each.value
+  location = "westus"
+}
+
+resource "azurerm_resource_group" "map" {
+  for_each = { for rg in var.resource_groups : rg.name => rg }
+
+  name     = each.key
+  location = each.value.location
+}
+
+module "network" {
+  source   = "./network"
+  for_each = var.names
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. anti-patterns",
+			Content: `
+variable "zones" {
+  type = list(string)
+}
+
+resource "azurerm_resource_group" "length" {
+  count = length(var.names)
+
+  name     = var.names[count.index]
+  location = "westus"
+}
+
+resource "azurerm_resource_group" "comparison" {
+  count = var.enabled == false ? 1 : 0
+
+  name     = "example"
+  location = "westus"
+}
+
+resource "azurerm_public_ip" "zones" {
+  for_each = var.zones
+
+  name = each.value
+}
+
+data "azurerm_subnet" "subnets" {
+  for_each = concat(var.subnets, ["default"])
+
+  name = each.value
+}
+
+module "network" {
+  source   = "./network"
+  for_each = var.enabled ? toset(["default"]) : []
+}
+
+module "dns" {
+  source   = "./dns"
+  for_each = var.disabled ? {} : { default = "dns" }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformCountVsForEachRule(),
+					Message: "`azurerm_resource_group.length` uses `count = length(var.names)`, use `for_each = toset(var.names)` for stable addresses",
+				},
+				{
+					Rule:    NewTerraformCountVsForEachRule(),
+					Message: "`azurerm_resource_group.comparison` compares a boolean in `count`, use `count = !var.enabled ? 1 : 0` instead",
+				},
+				{
+					Rule:    NewTerraformCountVsForEachRule(),
+					Message: "`azurerm_public_ip.zones` iterates a list with `for_each`, use `for_each = toset(var.zones)` instead",
+				},
+				{
+					Rule:    NewTerraformCountVsForEachRule(),
+					Message: "`data.azurerm_subnet.subnets` iterates a list with `for_each`, use `for_each = toset(concat(var.subnets, [\"default\"]))` instead",
+				},
+				{
+					Rule:    NewTerraformCountVsForEachRule(),
+					Message: "`module.network` is created conditionally with `for_each`, use `count = var.enabled ? 1 : 0` instead",
+				},
+				{
+					Rule:    NewTerraformCountVsForEachRule(),
+					Message: "`module.dns` is created conditionally with `for_each`, use `count = var.disabled ? 0 : 1` instead",
+				},
+			},
+		},
+	}
+	rule := NewTerraformCountVsForEachRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
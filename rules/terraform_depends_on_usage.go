@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = &TerraformDependsOnUsageRule{}
+
+// TerraformDependsOnUsageRule checks whether the entries of `depends_on` are necessary and valid
+type TerraformDependsOnUsageRule struct {
+	tflint.DefaultRule
+}
+
+// NewTerraformDependsOnUsageRule returns a new rule
+func NewTerraformDependsOnUsageRule() *TerraformDependsOnUsageRule {
+	return &TerraformDependsOnUsageRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformDependsOnUsageRule) Name() string {
+	return "terraform_depends_on_usage"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformDependsOnUsageRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformDependsOnUsageRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformDependsOnUsageRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks the `depends_on` of resources, data sources, modules and outputs
+func (r *TerraformDependsOnUsageRule) Check(runner tflint.Runner) error {
+	addresses, err := r.declaredAddresses(runner)
+	if err != nil {
+		return err
+	}
+	walker := &Walker{
+		Blocks: []BlockVisitor{{
+			TopLevel: true,
+			Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+				return r.checkBlock(ctx.Runner, block, addresses)
+			},
+		}},
+	}
+	return walker.Walk(runner)
+}
+
+// declaredAddresses returns the addresses of the resources, data sources and modules declared in the module,
+// including the ones declared in JSON files
+func (r *TerraformDependsOnUsageRule) declaredAddresses(runner tflint.Runner) (map[string]bool, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "resource", LabelNames: []string{"type", "name"}},
+			{Type: "data", LabelNames: []string{"type", "name"}},
+			{Type: "module", LabelNames: []string{"name"}},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return nil, err
+	}
+	addresses := make(map[string]bool)
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "resource":
+			addresses[strings.Join(block.Labels, ".")] = true
+		default:
+			addresses[block.Type+"."+strings.Join(block.Labels, ".")] = true
+		}
+	}
+	return addresses, nil
+}
+
+func (r *TerraformDependsOnUsageRule) checkBlock(runner tflint.Runner, block *hclsyntax.Block, addresses map[string]bool) error {
+	attr, ok := block.Body.Attributes["depends_on"]
+	if !ok {
+		return nil
+	}
+	tuple, ok := attr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok {
+		return nil
+	}
+	references := r.references(block, attr)
+	address := blockAddress(block)
+	seen := make(map[string]bool)
+	var err error
+	for _, expr := range tuple.Exprs {
+		traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			continue
+		}
+		entry := objectAddress(traversal.Traversal)
+		if entry == "" {
+			continue
+		}
+		var msg string
+		switch {
+		case seen[entry]:
+			msg = fmt.Sprintf("`depends_on` entry `%s` in `%s` is duplicated", entry, address)
+		case !addresses[entry]:
+			msg = fmt.Sprintf("`depends_on` entry `%s` in `%s` doesn't exist in the module", entry, address)
+		// a module is only partially referenced by its outputs, the other resources of the module are not waited for
+		case references[entry] && !strings.HasPrefix(entry, "module."):
+			msg = fmt.Sprintf("`depends_on` entry `%s` in `%s` is redundant since it's already referenced in the block", entry, address)
+		}
+		seen[entry] = true
+		if msg == "" {
+			continue
+		}
+		if subErr := runner.EmitIssue(r, msg, expr.Range()); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+// references returns the addresses of the objects referenced in the block except in `depends_on`
+func (r *TerraformDependsOnUsageRule) references(block *hclsyntax.Block, dependsOn *hclsyntax.Attribute) map[string]bool {
+	references := make(map[string]bool)
+	_ = hclsyntax.VisitAll(block.Body, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok || dependsOn.SrcRange.ContainsOffset(expr.SrcRange.Start.Byte) {
+			return nil
+		}
+		if address := objectAddress(expr.Traversal); address != "" {
+			references[address] = true
+		}
+		return nil
+	})
+	return references
+}
+
+// objectAddress returns the address of the resource, data source or module a traversal refers to,
+// e.g. `azurerm_subnet.example` for `azurerm_subnet.example[0].id` or `module.network` for `module.network.vnet_id`
+func objectAddress(traversal hcl.Traversal) string {
+	var names []string
+	for _, step := range traversal {
+		switch s := step.(type) {
+		case hcl.TraverseRoot:
+			names = append(names, s.Name)
+			continue
+		case hcl.TraverseAttr:
+			names = append(names, s.Name)
+			continue
+		}
+		break
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	switch names[0] {
+	case "var", "local", "each", "count", "self", "path", "terraform":
+		return ""
+	case "data":
+		if len(names) >= 3 {
+			return strings.Join(names[:3], ".")
+		}
+	default:
+		if len(names) >= 2 {
+			return strings.Join(names[:2], ".")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformDependsOnUsageRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. necessary depends_on",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westus"
+}
+
+resource "azurerm_role_assignment" "example" {
+  scope = "/subscriptions/example"
+}
+
+data "azurerm_client_config" "current" {}
+
+module "network" {
+  source = "./network"
+}
+
+resource "azurerm_storage_account" "example" {
+  name                = "example"
+  resource_group_name = azurerm_resource_group.example.name
+
+  depends_on = [
+    azurerm_role_assignment.example,
+    data.azurerm_client_config.current,
+    module.network,
+  ]
+}
+
+output "id" {
+  value      = azurerm_storage_account.example.id
+  depends_on = [azurerm_role_assignment.example]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. redundant, missing and duplicated entries",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westus"
+}
+
+module "network" {
+  source = "./network"
+}
+
+resource "azurerm_subnet" "example" {
+  resource_group_name  = azurerm_resource_group.example.name
+  virtual_network_name = module.network.vnet_name
+
+  dynamic "delegation" {
+    for_each = var.delegations
+    content {
+      name = data.azurerm_client_config.current.tenant_id
+    }
+  }
+
+  depends_on = [
+    azurerm_resource_group.example,
+    module.network,
+    azurerm_role_assignment.removed,
+    data.azurerm_client_config.current,
+    azurerm_resource_group.example,
+  ]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformDependsOnUsageRule(),
+					Message: "`depends_on` entry `azurerm_resource_group.example` in `azurerm_subnet.example` is redundant since it's already referenced in the block",
+				},
+				{
+					Rule:    NewTerraformDependsOnUsageRule(),
+					Message: "`depends_on` entry `azurerm_role_assignment.removed` in `azurerm_subnet.example` doesn't exist in the module",
+				},
+				{
+					Rule:    NewTerraformDependsOnUsageRule(),
+					Message: "`depends_on` entry `data.azurerm_client_config.current` in `azurerm_subnet.example` doesn't exist in the module",
+				},
+				{
+					Rule:    NewTerraformDependsOnUsageRule(),
+					Message: "`depends_on` entry `azurerm_resource_group.example` in `azurerm_subnet.example` is duplicated",
+				},
+			},
+		},
+	}
+	rule := NewTerraformDependsOnUsageRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformDependsOnUsageRule_JSONFile(t *testing.T) {
+	rule := NewTerraformDependsOnUsageRule()
+	runner := helper.TestRunner(t, map[string]string{
+		"config.tf": `
+resource "azurerm_storage_account" "example" {
+  name = "example"
+
+  depends_on = [
+    azurerm_role_assignment.example,
+    module.network,
+    azurerm_role_assignment.removed,
+  ]
+}`,
+		"config.tf.json": `
+{
+  "resource": {
+    "azurerm_role_assignment": {
+      "example": {
+        "scope": "/subscriptions/example"
+      }
+    }
+  },
+  "module": {
+    "network": {
+      "source": "./network"
+    }
+  }
+}`,
+	})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformDependsOnUsageRule(),
+			Message: "`depends_on` entry `azurerm_role_assignment.removed` in `azurerm_storage_account.example` doesn't exist in the module",
+		},
+	}, runner.Issues)
+}
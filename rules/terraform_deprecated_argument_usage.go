@@ -0,0 +1,171 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/Azure/tflint-ruleset-basic-ext/providerschema"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = &TerraformDeprecatedArgumentUsageRule{}
+
+// TerraformDeprecatedArgumentUsageRule checks whether deprecated arguments/nested blocks are used in resource/data blocks
+type TerraformDeprecatedArgumentUsageRule struct {
+	tflint.DefaultRule
+}
+
+type terraformDeprecatedArgumentUsageRuleConfig struct {
+	ProviderSchemaFile string `hclext:"provider_schema_file,optional"`
+}
+
+// NewTerraformDeprecatedArgumentUsageRule returns a new rule
+func NewTerraformDeprecatedArgumentUsageRule() *TerraformDeprecatedArgumentUsageRule {
+	return &TerraformDeprecatedArgumentUsageRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformDeprecatedArgumentUsageRule) Name() string {
+	return "terraform_deprecated_argument_usage"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformDeprecatedArgumentUsageRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformDeprecatedArgumentUsageRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformDeprecatedArgumentUsageRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether deprecated arguments/nested blocks are used based on the provider schema
+func (r *TerraformDeprecatedArgumentUsageRule) Check(runner tflint.Runner) error {
+	config := terraformDeprecatedArgumentUsageRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	if config.ProviderSchemaFile == "" {
+		return fmt.Errorf("`provider_schema_file` must be set for %s", r.Name())
+	}
+	schemas, err := LoadProviderSchemas(runner, config.ProviderSchemaFile)
+	if err != nil {
+		return err
+	}
+	visit := func(ctx *WalkContext, block *hclsyntax.Block) error {
+		return r.checkBlock(ctx.Runner, ctx.File, block, schemas)
+	}
+	walker := &Walker{
+		IncludeOverrideFiles: true,
+		Blocks: []BlockVisitor{
+			{Type: "resource", TopLevel: true, Visit: visit},
+			{Type: "data", TopLevel: true, Visit: visit},
+		},
+	}
+	return walker.Walk(runner)
+}
+
+func (r *TerraformDeprecatedArgumentUsageRule) checkBlock(runner tflint.Runner, file *hcl.File, block *hclsyntax.Block, schemas *providerschema.ProviderSchemas) error {
+	schema := schemas.BlockSchema(block.Type, block.Labels[0])
+	if schema == nil {
+		return nil
+	}
+	b := BuildResourceBlock(block, file, nil)
+	err := r.checkArgs(runner, b.Args, b.ParentBlockNames, schema)
+	if subErr := r.checkNestedBlocks(runner, b.NestedBlocks, schema); subErr != nil {
+		err = multierror.Append(err, subErr)
+	}
+	return err
+}
+
+func (r *TerraformDeprecatedArgumentUsageRule) checkArgs(runner tflint.Runner, args *Args, parentBlockNames []string, schema *providerschema.Block) error {
+	if args == nil {
+		return nil
+	}
+	var err error
+	for _, arg := range args.Args {
+		attr := schema.Attribute(arg.Name)
+		if attr == nil || !attr.Deprecated {
+			continue
+		}
+		subErr := runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` is deprecated in `%s`", arg.Name, strings.Join(parentBlockNames, ".")),
+			arg.Range,
+		)
+		if subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+func (r *TerraformDeprecatedArgumentUsageRule) checkNestedBlocks(runner tflint.Runner, nestedBlocks *NestedBlocks, parentSchema *providerschema.Block) error {
+	if nestedBlocks == nil {
+		return nil
+	}
+	var err error
+	for _, nb := range nestedBlocks.Blocks {
+		schema := parentSchema.NestedBlock(nb.Name)
+		if schema == nil {
+			continue
+		}
+		if subErr := r.checkNestedBlock(runner, nb, schema); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+func (r *TerraformDeprecatedArgumentUsageRule) checkNestedBlock(runner tflint.Runner, nb *NestedBlock, schema *providerschema.Block) error {
+	var err error
+	if schema.Deprecated {
+		subErr := runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` is deprecated in `%s`", nb.Name, strings.Join(nb.ParentBlockNames[:len(nb.ParentBlockNames)-1], ".")),
+			nb.DefRange(),
+		)
+		if subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	if nb.Block.Type == "dynamic" {
+		// the arguments of a dynamic block live in its `content` block, which shares the same block path
+		if nb.NestedBlocks == nil {
+			return err
+		}
+		for _, content := range nb.NestedBlocks.Blocks {
+			if content.Block.Type != "content" {
+				continue
+			}
+			if subErr := r.checkBlockBody(runner, content, schema); subErr != nil {
+				err = multierror.Append(err, subErr)
+			}
+		}
+		return err
+	}
+	if subErr := r.checkBlockBody(runner, nb, schema); subErr != nil {
+		err = multierror.Append(err, subErr)
+	}
+	return err
+}
+
+func (r *TerraformDeprecatedArgumentUsageRule) checkBlockBody(runner tflint.Runner, nb *NestedBlock, schema *providerschema.Block) error {
+	var err error
+	if subErr := r.checkArgs(runner, nb.Args, nb.ParentBlockNames, schema); subErr != nil {
+		err = multierror.Append(err, subErr)
+	}
+	if subErr := r.checkNestedBlocks(runner, nb.NestedBlocks, schema); subErr != nil {
+		err = multierror.Append(err, subErr)
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+const testProviderSchema = `
+{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.terraform.io/hashicorp/azurerm": {
+      "resource_schemas": {
+        "azurerm_kubernetes_cluster": {
+          "version": 2,
+          "block": {
+            "attributes": {
+              "name": { "type": "string", "required": true },
+              "api_server_authorized_ip_ranges": { "type": ["set", "string"], "optional": true, "deprecated": true }
+            },
+            "block_types": {
+              "default_node_pool": {
+                "nesting_mode": "list",
+                "block": {
+                  "attributes": {
+                    "name": { "type": "string", "required": true },
+                    "enable_auto_scaling": { "type": "bool", "optional": true, "deprecated": true }
+                  }
+                }
+              },
+              "addon_profile": {
+                "nesting_mode": "list",
+                "block": {
+                  "deprecated": true,
+                  "attributes": {}
+                }
+              }
+            }
+          }
+        }
+      },
+      "data_source_schemas": {
+        "azurerm_kubernetes_cluster": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "name": { "type": "string", "required": true },
+              "legacy_field": { "type": "string", "optional": true, "deprecated": true }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func Test_TerraformDeprecatedArgumentUsageRule(t *testing.T) {
+	schemaFile := filepath.Join(t.TempDir(), "provider_schema.json")
+	require.NoError(t, os.WriteFile(schemaFile, []byte(testProviderSchema), 0600))
+	config := fmt.Sprintf(`
+rule "terraform_deprecated_argument_usage" {
+  enabled              = true
+  provider_schema_file = %q
+}`, schemaFile)
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. no deprecated argument",
+			Content: `
+resource "azurerm_kubernetes_cluster" "this" {
+  name = "aks"
+
+  default_node_pool {
+    name = "default"
+  }
+}
+
+resource "azurerm_resource_group" "unknown_to_schema" {
+  legacy_field = "value"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. deprecated attributes and nested blocks",
+			Content: `
+resource "azurerm_kubernetes_cluster" "this" {
+  count = 1
+
+  name                            = "aks"
+  api_server_authorized_ip_ranges = []
+
+  default_node_pool {
+    name                = "default"
+    enable_auto_scaling = true
+  }
+  addon_profile {}
+}
+
+data "azurerm_kubernetes_cluster" "this" {
+  name         = "aks"
+  legacy_field = "value"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformDeprecatedArgumentUsageRule(),
+					Message: "`api_server_authorized_ip_ranges` is deprecated in `resource.azurerm_kubernetes_cluster`",
+				},
+				{
+					Rule:    NewTerraformDeprecatedArgumentUsageRule(),
+					Message: "`enable_auto_scaling` is deprecated in `resource.azurerm_kubernetes_cluster.default_node_pool`",
+				},
+				{
+					Rule:    NewTerraformDeprecatedArgumentUsageRule(),
+					Message: "`addon_profile` is deprecated in `resource.azurerm_kubernetes_cluster`",
+				},
+				{
+					Rule:    NewTerraformDeprecatedArgumentUsageRule(),
+					Message: "`legacy_field` is deprecated in `data.azurerm_kubernetes_cluster`",
+				},
+			},
+		},
+		{
+			Name: "3. deprecated attributes in dynamic block",
+			Content: `
+resource "azurerm_kubernetes_cluster" "this" {
+  name = "aks"
+
+  dynamic "default_node_pool" {
+    for_each = var.pools
+    iterator = pool
+
+    content {
+      name                = pool.value.name
+      enable_auto_scaling = pool.value.auto_scaling
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformDeprecatedArgumentUsageRule(),
+					Message: "`enable_auto_scaling` is deprecated in `resource.azurerm_kubernetes_cluster.default_node_pool`",
+				},
+			},
+		},
+	}
+	rule := NewTerraformDeprecatedArgumentUsageRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content, ".tflint.hcl": config})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformDeprecatedArgumentUsageRule_SchemaFileRequired(t *testing.T) {
+	rule := NewTerraformDeprecatedArgumentUsageRule()
+	runner := helper.TestRunner(t, map[string]string{"config.tf": `resource "azurerm_kubernetes_cluster" "this" {}`})
+	require.Error(t, rule.Check(runner))
+}
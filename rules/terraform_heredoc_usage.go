@@ -1,14 +1,12 @@
 package rules
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
-	"gopkg.in/yaml.v3"
+	"slices"
 	"strings"
 )
 
@@ -19,6 +17,12 @@ type TerraformHeredocUsageRule struct {
 	tflint.DefaultRule
 }
 
+type terraformHeredocUsageRuleConfig struct {
+	Formats []string `hclext:"formats,optional"`
+}
+
+var defaultHeredocFormats = []string{heredocFormatPolicy, heredocFormatJSON, heredocFormatYAML}
+
 // NewTerraformHeredocUsageRule returns a new rule
 func NewTerraformHeredocUsageRule() *TerraformHeredocUsageRule {
 	return &TerraformHeredocUsageRule{}
@@ -33,7 +37,19 @@ func (r *TerraformHeredocUsageRule) Severity() tflint.Severity {
 }
 
 func (r *TerraformHeredocUsageRule) Check(runner tflint.Runner) error {
-	return ForFiles(runner, r.CheckFile)
+	config := terraformHeredocUsageRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	if config.Formats == nil {
+		config.Formats = defaultHeredocFormats
+	}
+	for _, format := range config.Formats {
+		if !slices.Contains(heredocFormats, format) {
+			return fmt.Errorf("unknown format `%s` for %s, valid formats are %s", format, r.Name(), strings.Join(heredocFormats, ", "))
+		}
+	}
+	return r.walker(config.Formats).Walk(runner)
 }
 
 // Name returns the rule name
@@ -42,62 +58,78 @@ func (r *TerraformHeredocUsageRule) Name() string {
 }
 
 func (r *TerraformHeredocUsageRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
-	body, ok := file.Body.(*hclsyntax.Body)
-	if !ok {
-		logger.Debug("skip terraform_heredoc_usage since it's not hcl file")
-		return nil
+	return r.walker(defaultHeredocFormats).WalkFile(runner, file)
+}
+
+func (r *TerraformHeredocUsageRule) walker(formats []string) *Walker {
+	return &Walker{
+		IncludeOverrideFiles: true,
+		File: func(ctx *WalkContext) error {
+			return r.checkFile(ctx, formats)
+		},
 	}
-	fileName := body.Range().Filename
-	tokens, diags := hclsyntax.LexConfig(file.Bytes, fileName, hcl.InitialPos)
-	if diags.HasErrors() {
-		return diags
+}
+
+func (r *TerraformHeredocUsageRule) checkFile(ctx *WalkContext, formats []string) error {
+	tokens, err := ctx.Tokens()
+	if err != nil {
+		return err
 	}
-	var err error
-	var hereDocStartRange hcl.Range
-	var heredoc string
-	var inHeredoc bool
-	for _, token := range tokens {
-		switch token.Type {
-		case hclsyntax.TokenOHeredoc:
-			inHeredoc = true
-			heredoc = ""
-			hereDocStartRange = token.Range
-		case hclsyntax.TokenCHeredoc:
-			inHeredoc = false
-			if subErr := r.checkHeredocIsJSONOrYAML(runner, heredoc, hereDocStartRange); subErr != nil {
-				err = multierror.Append(err, subErr)
-			}
-		case hclsyntax.TokenStringLit:
-			if inHeredoc {
-				heredoc = fmt.Sprintf("%s%s", heredoc, string(token.Bytes))
-			}
+	for _, heredoc := range collectHeredocs(ctx.File.Bytes, tokens) {
+		if subErr := r.checkHeredoc(ctx.Runner, heredoc, formats); subErr != nil {
+			err = multierror.Append(err, subErr)
 		}
 	}
 	return err
 }
 
-func (r *TerraformHeredocUsageRule) checkHeredocIsJSONOrYAML(runner tflint.Runner, heredoc string, heredocStartRange hcl.Range) error {
-	prunedHereDoc := strings.ReplaceAll(heredoc, "\t", "")
-	prunedHereDoc = strings.ReplaceAll(prunedHereDoc, " ", "")
-	prunedHereDoc = strings.ReplaceAll(prunedHereDoc, "\n", "")
-	if prunedHereDoc == "" {
-		return nil
-	}
-	bytes := []byte(heredoc)
-	if json.Valid(bytes) {
-		return runner.EmitIssue(
+func (r *TerraformHeredocUsageRule) checkHeredoc(runner tflint.Runner, heredoc *heredocTemplate, formats []string) error {
+	switch heredoc.Format(formats) {
+	case heredocFormatPolicy:
+		return runner.EmitIssueWithFix(
+			r,
+			"for policy documents, instead of HEREDOC, use the `jsonencode` function or a policy document data source, e.g. `aws_iam_policy_document`",
+			heredoc.StartRange,
+			r.fix(heredoc, "jsonencode"),
+		)
+	case heredocFormatJSON:
+		return runner.EmitIssueWithFix(
 			r,
 			"for JSON, instead of HEREDOC, use a combination of a `local` and the `jsonencode` function",
-			heredocStartRange,
+			heredoc.StartRange,
+			r.fix(heredoc, "jsonencode"),
 		)
-	}
-	temp := map[string]interface{}{}
-	if yaml.Unmarshal(bytes, &temp) == nil {
-		return runner.EmitIssue(
+	case heredocFormatYAML:
+		return runner.EmitIssueWithFix(
 			r,
 			"for YAML, instead of HEREDOC, use a combination of a `local` and the `yamlencode` function",
-			heredocStartRange,
+			heredoc.StartRange,
+			r.fix(heredoc, "yamlencode"),
+		)
+	case heredocFormatXML:
+		return runner.EmitIssue(
+			r,
+			"for XML, instead of HEREDOC, move the document into a template file and render it with the `templatefile` function",
+			heredoc.StartRange,
+		)
+	case heredocFormatTOML:
+		return runner.EmitIssue(
+			r,
+			"for TOML, instead of HEREDOC, move the document into a template file and render it with the `templatefile` function",
+			heredoc.StartRange,
 		)
 	}
 	return nil
 }
+
+// fix replaces the heredoc with the equivalent call of the encode function, it's not supported if the conversion is unsafe
+func (r *TerraformHeredocUsageRule) fix(heredoc *heredocTemplate, function string) func(tflint.Fixer) error {
+	return func(f tflint.Fixer) error {
+		expr, err := heredoc.ToEncodeCall(function)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("skip fixing heredoc at %s: %s", heredoc.StartRange, err))
+			return tflint.ErrFixNotSupported
+		}
+		return f.ReplaceText(heredoc.Range, expr)
+	}
+}
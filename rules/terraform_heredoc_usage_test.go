@@ -1,8 +1,10 @@
 package rules
 
 import (
-	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 )
 
 func Test_TerraformHeredocUsageRule(t *testing.T) {
@@ -227,3 +229,360 @@ line1
 		})
 	}
 }
+
+func Test_TerraformHeredocUsageRule_Fix(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected map[string]string
+	}{
+		{
+			Name: "1. JSON with interpolations",
+			Content: `
+resource "azurerm_policy_definition" "example" {
+  policy_rule = <<-JSON
+  {
+    "if": {
+      "field": "location",
+      "notIn": ["${var.location}", "global"]
+    },
+    "then": {
+      "effect": "${var.effect}",
+      "details": {
+        "name": "diag-${var.name}",
+        "count": 2,
+        "enabled": true,
+        "tags": null,
+        "raw": "$${literal}"
+      }
+    }
+  }
+  JSON
+}`,
+			Expected: map[string]string{"config.tf": `
+resource "azurerm_policy_definition" "example" {
+  policy_rule = jsonencode({
+    if = {
+      field = "location"
+      notIn = [
+        "${var.location}",
+        "global",
+      ]
+    }
+    then = {
+      effect = "${var.effect}"
+      details = {
+        name    = "diag-${var.name}"
+        count   = 2
+        enabled = true
+        tags    = null
+        raw     = "$${literal}"
+      }
+    }
+  })
+}`},
+		},
+		{
+			Name: "2. YAML",
+			Content: `
+resource "kubernetes_manifest" "example" {
+  manifest = <<YAML
+kind: ConfigMap
+metadata:
+  name: ${var.name}
+  labels:
+    app.kubernetes.io/name: example
+data:
+  ports:
+  - 80
+  - 443
+YAML
+}`,
+			Expected: map[string]string{"config.tf": `
+resource "kubernetes_manifest" "example" {
+  manifest = yamlencode({
+    kind = "ConfigMap"
+    metadata = {
+      name = var.name
+      labels = {
+        "app.kubernetes.io/name" = "example"
+      }
+    }
+    data = {
+      ports = [
+        80,
+        443,
+      ]
+    }
+  })
+}`},
+		},
+		{
+			Name: "3. template directives are not fixed",
+			Content: `
+resource "azurerm_policy_definition" "example" {
+  policy_rule = <<-JSON
+  {
+    "effect": "%{ if var.audit }audit%{ else }deny%{ endif }"
+  }
+  JSON
+}`,
+			Expected: map[string]string{},
+		},
+		{
+			Name: "4. YAML hexadecimal numbers are not fixed",
+			Content: `
+resource "kubernetes_manifest" "example" {
+  manifest = <<YAML
+kind: ConfigMap
+data:
+  mask: 0x1F
+YAML
+}`,
+			Expected: map[string]string{},
+		},
+		{
+			Name: "5. YAML infinity is not fixed",
+			Content: `
+resource "kubernetes_manifest" "example" {
+  manifest = <<YAML
+kind: ConfigMap
+data:
+  limit: .inf
+YAML
+}`,
+			Expected: map[string]string{},
+		},
+		{
+			Name: "6. YAML custom tags are not fixed",
+			Content: `
+resource "kubernetes_manifest" "example" {
+  manifest = <<YAML
+kind: ConfigMap
+data:
+  bucket: !Ref foo
+YAML
+}`,
+			Expected: map[string]string{},
+		},
+		{
+			Name: "7. YAML custom tags of collections are not fixed",
+			Content: `
+resource "kubernetes_manifest" "example" {
+  manifest = <<YAML
+kind: ConfigMap
+data:
+  zones: !GetAZs
+  - a
+YAML
+}`,
+			Expected: map[string]string{},
+		},
+		{
+			Name: "8. JSON with bare and quoted interpolations",
+			Content: `
+resource "aws_iam_policy" "example" {
+  policy = <<EOF
+{
+  "Resource": ${jsonencode(var.arns)},
+  "Port": "${var.port}",
+  "Count": ${var.instance_count}
+}
+EOF
+}`,
+			Expected: map[string]string{"config.tf": `
+resource "aws_iam_policy" "example" {
+  policy = jsonencode({
+    Resource = var.arns
+    Port     = "${var.port}"
+    Count    = var.instance_count
+  })
+}`},
+		},
+	}
+	rule := NewTerraformHeredocUsageRule()
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			if len(runner.Issues) != 1 {
+				t.Fatalf("Expected one issue, got %d", len(runner.Issues))
+			}
+			helper.AssertChanges(t, tc.Expected, runner.Changes())
+		})
+	}
+}
+
+func Test_TerraformHeredocUsageRule_Formats(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. interpolations are substituted before detection",
+			Content: `
+locals {
+  settings = <<-JSON
+  {
+    "name": "${var.name}",
+    "count": ${var.count}
+  }
+  JSON
+  text = <<-TEXT
+  name: ${var.name}
+  ${var.description}
+  TEXT
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformHeredocUsageRule(),
+					Message: "for JSON, instead of HEREDOC, use a combination of a `local` and the `jsonencode` function",
+				},
+			},
+		},
+		{
+			Name: "2. policy documents",
+			Content: `
+resource "aws_iam_policy" "example" {
+  policy = <<-POLICY
+  {
+    "Version": "2012-10-17",
+    "Statement": [
+      %{ for bucket in var.buckets }
+      {
+        "Effect": "Allow",
+        "Action": "s3:GetObject",
+        "Resource": "arn:aws:s3:::${bucket}/*"
+      },
+      %{ endfor }
+    ]
+  }
+  POLICY
+}
+
+resource "azurerm_policy_definition" "example" {
+  policy_rule = <<-POLICY
+  {
+    "if": {
+      "field": "location",
+      "notIn": ${jsonencode(var.locations)}
+    },
+    "then": {
+      "effect": "deny"
+    }
+  }
+  POLICY
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformHeredocUsageRule(),
+					Message: "for policy documents, instead of HEREDOC, use the `jsonencode` function or a policy document data source, e.g. `aws_iam_policy_document`",
+				},
+				{
+					Rule:    NewTerraformHeredocUsageRule(),
+					Message: "for policy documents, instead of HEREDOC, use the `jsonencode` function or a policy document data source, e.g. `aws_iam_policy_document`",
+				},
+			},
+		},
+		{
+			Name: "3. XML and TOML",
+			Config: `
+rule "terraform_heredoc_usage" {
+  enabled = true
+  formats = ["xml", "toml"]
+}`,
+			Content: `
+locals {
+  xml = <<-XML
+  <?xml version="1.0"?>
+  <configuration>
+    <name>${var.name}</name>
+  </configuration>
+  XML
+  toml = <<-TOML
+  # settings
+  [server]
+  host = "${var.host}"
+  port = ${var.port}
+  tags = ["a", 'b']
+
+  [server.tls]
+  enabled = true
+  TOML
+  json = <<-JSON
+  {"name": "example"}
+  JSON
+  text = <<-TEXT
+  hello, world!
+  TEXT
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformHeredocUsageRule(),
+					Message: "for XML, instead of HEREDOC, move the document into a template file and render it with the `templatefile` function",
+				},
+				{
+					Rule:    NewTerraformHeredocUsageRule(),
+					Message: "for TOML, instead of HEREDOC, move the document into a template file and render it with the `templatefile` function",
+				},
+			},
+		},
+	}
+	rule := NewTerraformHeredocUsageRule()
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"config.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformHeredocUsageRule_UnknownFormat(t *testing.T) {
+	rule := NewTerraformHeredocUsageRule()
+	runner := helper.TestRunner(t, map[string]string{
+		".tflint.hcl": `
+rule "terraform_heredoc_usage" {
+  enabled = true
+  formats = ["ini"]
+}`,
+		"config.tf": "",
+	})
+	if err := rule.Check(runner); err == nil {
+		t.Fatal("Expected an error for the unknown format")
+	}
+}
+
+func Test_TerraformHeredocUsageRule_OverrideFile(t *testing.T) {
+	rule := NewTerraformHeredocUsageRule()
+	runner := helper.TestRunner(t, map[string]string{"main_override.tf": `
+locals {
+  config = <<EOT
+{"a": 1}
+EOT
+}`})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformHeredocUsageRule(),
+			Message: "for JSON, instead of HEREDOC, use a combination of a `local` and the `jsonencode` function",
+			Range: hcl.Range{
+				Filename: "main_override.tf",
+				Start:    hcl.Pos{Line: 3, Column: 12, Byte: 21},
+				End:      hcl.Pos{Line: 4, Column: 1, Byte: 28},
+			},
+		},
+	}, runner.Issues)
+}
@@ -0,0 +1,251 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = &TerraformLifecycleBlockLayoutRule{}
+
+// TerraformLifecycleBlockLayoutRule checks the layout of the contents of `lifecycle` blocks
+type TerraformLifecycleBlockLayoutRule struct {
+	tflint.DefaultRule
+}
+
+type terraformLifecycleBlockLayoutRuleConfig struct {
+	IgnoreChangesAllAllowlist []string `hclext:"ignore_changes_all_allowlist,optional"`
+}
+
+// lifecycleOrder is the expected order of the arguments and blocks in `lifecycle`, unknown ones go last
+var lifecycleOrder = []string{
+	"create_before_destroy",
+	"prevent_destroy",
+	"ignore_changes",
+	"replace_triggered_by",
+	"precondition",
+	"postcondition",
+}
+
+// lifecycleEntry is an argument or a nested block in `lifecycle`
+type lifecycleEntry struct {
+	name string
+	rng  hcl.Range
+}
+
+// NewTerraformLifecycleBlockLayoutRule returns a new rule
+func NewTerraformLifecycleBlockLayoutRule() *TerraformLifecycleBlockLayoutRule {
+	return &TerraformLifecycleBlockLayoutRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformLifecycleBlockLayoutRule) Name() string {
+	return "terraform_lifecycle_block_layout"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformLifecycleBlockLayoutRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformLifecycleBlockLayoutRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *TerraformLifecycleBlockLayoutRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks the order of the contents of `lifecycle` blocks and the entries of `ignore_changes`
+func (r *TerraformLifecycleBlockLayoutRule) Check(runner tflint.Runner) error {
+	config := terraformLifecycleBlockLayoutRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	walker := &Walker{
+		IncludeOverrideFiles: true,
+		Blocks: []BlockVisitor{{
+			Type: "lifecycle",
+			Visit: func(ctx *WalkContext, lifecycle *hclsyntax.Block) error {
+				block := ctx.Parent()
+				if len(ctx.Parents) != 1 || block.Type != "resource" && block.Type != "data" {
+					return nil
+				}
+				return r.checkLifecycle(ctx.Runner, ctx.File, block, lifecycle, config)
+			},
+		}},
+	}
+	return walker.Walk(runner)
+}
+
+func (r *TerraformLifecycleBlockLayoutRule) checkLifecycle(runner tflint.Runner, file *hcl.File, block, lifecycle *hclsyntax.Block, config terraformLifecycleBlockLayoutRuleConfig) error {
+	ordered, err := r.checkOrder(runner, file, block, lifecycle)
+	attr, ok := lifecycle.Body.Attributes["ignore_changes"]
+	if !ok {
+		return err
+	}
+	if subErr := r.checkIgnoreChanges(runner, file, block, attr, ordered, config); subErr != nil {
+		err = multierror.Append(err, subErr)
+	}
+	return err
+}
+
+// checkOrder emits an issue with a fix if the contents of `lifecycle` are not in the expected order, and reports whether they are
+func (r *TerraformLifecycleBlockLayoutRule) checkOrder(runner tflint.Runner, file *hcl.File, block, lifecycle *hclsyntax.Block) (bool, error) {
+	var entries []lifecycleEntry
+	for _, attr := range lifecycle.Body.Attributes {
+		entries = append(entries, lifecycleEntry{name: attr.Name, rng: attr.SrcRange})
+	}
+	for _, nestedBlock := range lifecycle.Body.Blocks {
+		entries = append(entries, lifecycleEntry{name: nestedBlock.Type, rng: nestedBlock.Range()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].rng.Start.Byte < entries[j].rng.Start.Byte
+	})
+	sortedEntries := make([]lifecycleEntry, len(entries))
+	copy(sortedEntries, entries)
+	sort.SliceStable(sortedEntries, func(i, j int) bool {
+		return lifecycleRank(sortedEntries[i].name) < lifecycleRank(sortedEntries[j].name)
+	})
+	if slices.Equal(entries, sortedEntries) {
+		return true, nil
+	}
+	var ranges, sortedRanges []hcl.Range
+	var sortedTxts []string
+	for i, entry := range sortedEntries {
+		ranges = append(ranges, entries[i].rng)
+		sortedRanges = append(sortedRanges, entry.rng)
+		sortedTxts = append(sortedTxts, string(entry.rng.SliceBytes(file.Bytes)))
+	}
+	sortedTxt := string(hclwrite.Format([]byte(fmt.Sprintf("lifecycle {\n%s\n}", strings.Join(sortedTxts, "\n")))))
+	return false, runner.EmitIssueWithFix(
+		r,
+		fmt.Sprintf("Recommended `lifecycle` order of `%s`:\n%s", blockAddress(block), sortedTxt),
+		lifecycle.DefRange(),
+		func(f tflint.Fixer) error {
+			return ReorderWithComments(f, file, ranges, sortedRanges)
+		},
+	)
+}
+
+func lifecycleRank(name string) int {
+	if i := slices.Index(lifecycleOrder, name); i >= 0 {
+		return i
+	}
+	return len(lifecycleOrder)
+}
+
+func (r *TerraformLifecycleBlockLayoutRule) checkIgnoreChanges(runner tflint.Runner, file *hcl.File, block *hclsyntax.Block, attr *hclsyntax.Attribute, fixable bool, config terraformLifecycleBlockLayoutRuleConfig) error {
+	address := blockAddress(block)
+	if traversal, ok := attr.Expr.(*hclsyntax.ScopeTraversalExpr); ok && traversal.Traversal.RootName() == "all" {
+		if slices.Contains(config.IgnoreChangesAllAllowlist, address) || slices.Contains(config.IgnoreChangesAllAllowlist, block.Labels[0]) {
+			return nil
+		}
+		return runner.EmitIssue(r, fmt.Sprintf("`ignore_changes = all` in `%s` hides every drift, list the arguments to ignore instead", address), attr.SrcRange)
+	}
+	tuple, ok := attr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok {
+		return nil
+	}
+	var entries []string
+	for _, expr := range tuple.Exprs {
+		entries = append(entries, exprText(file, expr))
+	}
+	sortedEntries := slices.Clone(entries)
+	sort.Strings(sortedEntries)
+	sortedEntries = slices.Compact(sortedEntries)
+	if slices.Equal(entries, sortedEntries) {
+		return nil
+	}
+	sortedTxt, sortable := r.sortedIgnoreChanges(file, tuple, entries)
+	return runner.EmitIssueWithFix(
+		r,
+		fmt.Sprintf("`ignore_changes` of `%s` is expected to be sorted and deduplicated: %s", address, strings.Join(sortedEntries, ", ")),
+		attr.SrcRange,
+		func(f tflint.Fixer) error {
+			if !fixable {
+				// the lifecycle block is rewritten by the order fix, run the fix again to sort the entries
+				return tflint.ErrFixNotSupported
+			}
+			if !sortable {
+				return tflint.ErrFixNotSupported
+			}
+			return f.ReplaceText(tuple.SrcRange, sortedTxt)
+		},
+	)
+}
+
+// sortedIgnoreChanges prints the tuple with its entries sorted and deduplicated, the comments attached to the entries
+// are moved along with them, it fails if a comment would be lost, e.g. the comment of a removed duplicate
+func (r *TerraformLifecycleBlockLayoutRule) sortedIgnoreChanges(file *hcl.File, tuple *hclsyntax.TupleConsExpr, entries []string) (string, bool) {
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(x, y int) int {
+		return strings.Compare(entries[x], entries[y])
+	})
+	comments := commentTokens(file)
+	commentCount := 0
+	for _, comment := range comments {
+		if tuple.SrcRange.ContainsOffset(comment.Range.Start.Byte) {
+			commentCount++
+		}
+	}
+	multiline := tuple.SrcRange.Start.Line != tuple.SrcRange.End.Line
+	if commentCount > 0 && !multiline {
+		return "", false
+	}
+	seen := make(map[string]bool)
+	var items []string
+	for _, i := range order {
+		itemRange := tupleItemRange(file, tuple.Exprs[i])
+		leading, trailing := attachedComments(file, comments, itemRange)
+		attached := len(leading) + len(trailing)
+		if seen[entries[i]] {
+			if attached > 0 {
+				return "", false
+			}
+			continue
+		}
+		seen[entries[i]] = true
+		commentCount -= attached
+		if multiline {
+			items = append(items, withComments(file, itemRange, entries[i]+","))
+		} else {
+			items = append(items, entries[i])
+		}
+	}
+	if commentCount != 0 {
+		// comments which aren't attached to any entry
+		return "", false
+	}
+	if multiline {
+		return fmt.Sprintf("[\n%s\n]", strings.Join(items, "\n")), true
+	}
+	return fmt.Sprintf("[%s]", strings.Join(items, ", ")), true
+}
+
+// tupleItemRange returns the range of a tuple item including the comma following it on the same line
+func tupleItemRange(file *hcl.File, expr hclsyntax.Expression) hcl.Range {
+	r := expr.Range()
+	rest := file.Bytes[r.End.Byte:]
+	trimmed := bytes.TrimLeft(rest, " \t")
+	if len(trimmed) == 0 || trimmed[0] != ',' {
+		return r
+	}
+	width := len(rest) - len(trimmed) + 1
+	r.End = hcl.Pos{Line: r.End.Line, Column: r.End.Column + width, Byte: r.End.Byte + width}
+	return r
+}
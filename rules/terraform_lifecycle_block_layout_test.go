@@ -0,0 +1,231 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformLifecycleBlockLayoutRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. proper layout",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westus"
+
+  lifecycle {
+    create_before_destroy = true
+    prevent_destroy       = true
+    ignore_changes        = [location, tags["owner"]]
+    replace_triggered_by  = [null_resource.trigger]
+
+    precondition {
+      condition     = var.location != ""
+      error_message = "location is required"
+    }
+    postcondition {
+      condition     = self.id != ""
+      error_message = "id is required"
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. improper layout",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westus"
+
+  lifecycle {
+    postcondition {
+      condition     = self.id != ""
+      error_message = "id is required"
+    }
+    ignore_changes  = [tags, location, tags]
+    prevent_destroy = true
+  }
+}
+
+resource "azurerm_resource_group" "all" {
+  name     = "example"
+  location = "westus"
+
+  lifecycle {
+    ignore_changes = all
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule: NewTerraformLifecycleBlockLayoutRule(),
+					Message: "Recommended `lifecycle` order of `azurerm_resource_group.example`:" + `
+lifecycle {
+  prevent_destroy = true
+  ignore_changes  = [tags, location, tags]
+  postcondition {
+    condition     = self.id != ""
+    error_message = "id is required"
+  }
+}`,
+				},
+				{
+					Rule:    NewTerraformLifecycleBlockLayoutRule(),
+					Message: "`ignore_changes` of `azurerm_resource_group.example` is expected to be sorted and deduplicated: location, tags",
+				},
+				{
+					Rule:    NewTerraformLifecycleBlockLayoutRule(),
+					Message: "`ignore_changes = all` in `azurerm_resource_group.all` hides every drift, list the arguments to ignore instead",
+				},
+			},
+		},
+		{
+			Name: "3. allowlisted ignore_changes = all",
+			Config: `
+rule "terraform_lifecycle_block_layout" {
+  enabled                      = true
+  ignore_changes_all_allowlist = ["azurerm_resource_group.all", "azurerm_kubernetes_cluster"]
+}`,
+			Content: `
+resource "azurerm_resource_group" "all" {
+  lifecycle {
+    ignore_changes = all
+  }
+}
+
+resource "azurerm_kubernetes_cluster" "aks" {
+  lifecycle {
+    ignore_changes = all
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+	rule := NewTerraformLifecycleBlockLayoutRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"config.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformLifecycleBlockLayoutRule_Fix(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected string
+	}{
+		{
+			Name: "1. reorder lifecycle",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  lifecycle {
+    # must stay
+    prevent_destroy       = true
+    create_before_destroy = true
+  }
+}`,
+			Expected: `
+resource "azurerm_resource_group" "example" {
+  lifecycle {
+    create_before_destroy = true
+    # must stay
+    prevent_destroy = true
+  }
+}`,
+		},
+		{
+			Name: "2. sort ignore_changes",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  lifecycle {
+    ignore_changes = [
+      tags,
+      location,
+      tags,
+    ]
+  }
+}`,
+			Expected: `
+resource "azurerm_resource_group" "example" {
+  lifecycle {
+    ignore_changes = [
+      location,
+      tags,
+    ]
+  }
+}`,
+		},
+		{
+			Name: "3. keep comments when sorting ignore_changes",
+			Content: `
+resource "azurerm_resource_group" "example" {
+  lifecycle {
+    ignore_changes = [
+      tags, # managed by azure policy
+      # changed by the failover
+      location,
+    ]
+  }
+}`,
+			Expected: `
+resource "azurerm_resource_group" "example" {
+  lifecycle {
+    ignore_changes = [
+      # changed by the failover
+      location,
+      tags, # managed by azure policy
+    ]
+  }
+}`,
+		},
+	}
+	rule := NewTerraformLifecycleBlockLayoutRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			helper.AssertChanges(t, map[string]string{"config.tf": tc.Expected}, runner.Changes())
+		})
+	}
+}
+
+func Test_TerraformLifecycleBlockLayoutRule_FixNotSupported(t *testing.T) {
+	rule := NewTerraformLifecycleBlockLayoutRule()
+	runner := helper.TestRunner(t, map[string]string{"config.tf": `
+resource "azurerm_resource_group" "example" {
+  lifecycle {
+    ignore_changes = [
+      tags,
+      location,
+      tags, # the comment of a duplicate
+    ]
+  }
+}`})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(runner.Issues) != 1 {
+		t.Fatalf("Expected one issue, got %d", len(runner.Issues))
+	}
+	helper.AssertChanges(t, map[string]string{}, runner.Changes())
+}
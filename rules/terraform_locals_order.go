@@ -2,12 +2,10 @@ package rules
 
 import (
 	"fmt"
-	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"sort"
 	"strings"
 
 	"github.com/Azure/tflint-ruleset-basic-ext/project"
-	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -46,35 +44,17 @@ func (r *TerraformLocalsOrderRule) Link() string {
 
 // Check checks whether single line comments is used
 func (r *TerraformLocalsOrderRule) Check(runner tflint.Runner) error {
-	files, err := runner.GetFiles()
-	if err != nil {
-		return err
-	}
-	for _, file := range files {
-		if subErr := r.checkFile(runner, file); subErr != nil {
-			err = multierror.Append(err, subErr)
-		}
+	walker := &Walker{
+		IncludeOverrideFiles: true,
+		Blocks: []BlockVisitor{{
+			Type:     "locals",
+			TopLevel: true,
+			Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+				return r.checkLocalsOrder(ctx.Runner, block)
+			},
+		}},
 	}
-	return err
-}
-
-func (r *TerraformLocalsOrderRule) checkFile(runner tflint.Runner, file *hcl.File) error {
-	body, ok := file.Body.(*hclsyntax.Body)
-	if !ok {
-		logger.Debug("skip terraform_locals_order check since it's not hcl file")
-		return nil
-	}
-	blocks := body.Blocks
-	var err error
-	for _, block := range blocks {
-		if block.Type != "locals" {
-			continue
-		}
-		if subErr := r.checkLocalsOrder(runner, block); subErr != nil {
-			err = multierror.Append(err, subErr)
-		}
-	}
-	return err
+	return walker.Walk(runner)
 }
 
 func (r *TerraformLocalsOrderRule) checkLocalsOrder(runner tflint.Runner, block *hclsyntax.Block) error {
@@ -86,24 +66,32 @@ func (r *TerraformLocalsOrderRule) checkLocalsOrder(runner tflint.Runner, block
 }
 
 func (r *TerraformLocalsOrderRule) suggestedOrder(runner tflint.Runner, block *hclsyntax.Block, attributes []*hclsyntax.Attribute) error {
-	sort.Slice(attributes, func(x, y int) bool {
-		return attributes[x].Name < attributes[y].Name
+	sortedAttributes := make([]*hclsyntax.Attribute, len(attributes))
+	copy(sortedAttributes, attributes)
+	sort.Slice(sortedAttributes, func(x, y int) bool {
+		return sortedAttributes[x].Name < sortedAttributes[y].Name
 	})
 	file, err := runner.GetFile(block.Range().Filename)
 	if err != nil {
 		return err
 	}
+	var ranges, sortedRanges []hcl.Range
 	var localsHclTxts []string
-	for _, a := range attributes {
+	for i, a := range sortedAttributes {
+		ranges = append(ranges, attributes[i].SrcRange)
+		sortedRanges = append(sortedRanges, a.SrcRange)
 		localsHclTxts = append(localsHclTxts, string(a.SrcRange.SliceBytes(file.Bytes)))
 	}
 	localsHclTxt := strings.Join(localsHclTxts, "\n")
 	localsHclTxt = fmt.Sprintf("%s {\n%s\n}", block.Type, localsHclTxt)
 	formattedTxt := string(hclwrite.Format([]byte(localsHclTxt)))
-	return runner.EmitIssue(
+	return runner.EmitIssueWithFix(
 		r,
 		fmt.Sprintf("Recommended locals order:\n%s", formattedTxt),
 		block.DefRange(),
+		func(f tflint.Fixer) error {
+			return ReorderWithComments(f, file, ranges, sortedRanges)
+		},
 	)
 }
 
@@ -111,3 +111,65 @@ locals {
 		})
 	}
 }
+
+func Test_TerraformLocalsOrderRule_Fix(t *testing.T) {
+	content := `
+locals {
+  # all instances
+  instance_ids = concat(aws_instance.blue.*.id, aws_instance.green.*.id)
+  common_tags = {
+    Service = local.service_name
+  } # shared by all resources
+  bar = "bar"
+}`
+	expected := `
+locals {
+  bar = "bar"
+  common_tags = {
+    Service = local.service_name
+  } # shared by all resources
+  # all instances
+  instance_ids = concat(aws_instance.blue.*.id, aws_instance.green.*.id)
+}`
+
+	rule := NewTerraformLocalsOrderRule()
+	runner := helper.TestRunner(t, map[string]string{"config.tf": content})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertChanges(t, map[string]string{"config.tf": expected}, runner.Changes())
+}
+
+func Test_TerraformLocalsOrderRule_FixBlockComment(t *testing.T) {
+	content := `
+locals {
+  /*
+    tags shared by all resources,
+    see the tagging policy
+  */
+  tags = {}
+
+  /* the suffix # of all names */ suffix = "dev"
+  # the prefix
+  prefix = "example"
+}`
+	expected := `
+locals {
+  # the prefix
+  prefix = "example"
+
+  /* the suffix # of all names */ suffix = "dev"
+  /*
+    tags shared by all resources,
+    see the tagging policy
+  */
+  tags = {}
+}`
+
+	rule := NewTerraformLocalsOrderRule()
+	runner := helper.TestRunner(t, map[string]string{"config.tf": content})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertChanges(t, map[string]string{"config.tf": expected}, runner.Changes())
+}
@@ -0,0 +1,178 @@
+package rules
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ tflint.Rule = &TerraformModuleSourcePinningRule{}
+
+// TerraformModuleSourcePinningRule checks whether the sources of module blocks are pinned to a fixed version
+type TerraformModuleSourcePinningRule struct {
+	tflint.DefaultRule
+}
+
+type terraformModuleSourcePinningRuleConfig struct {
+	AllowedUnpinnedSources []string `hclext:"allowed_unpinned_sources,optional"`
+}
+
+type moduleSourceType string
+
+const (
+	moduleSourceRegistry moduleSourceType = "registry"
+	moduleSourceGit      moduleSourceType = "git"
+	moduleSourceLocal    moduleSourceType = "local"
+	moduleSourceHTTP     moduleSourceType = "http"
+	moduleSourceUnknown  moduleSourceType = "unknown"
+)
+
+var (
+	registrySourceSegment = regexp.MustCompile(`^[0-9A-Za-z_-]+$`)
+	commitSHA             = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+)
+
+// NewTerraformModuleSourcePinningRule returns a new rule
+func NewTerraformModuleSourcePinningRule() *TerraformModuleSourcePinningRule {
+	return &TerraformModuleSourcePinningRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformModuleSourcePinningRule) Name() string {
+	return "terraform_module_source_pinning"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformModuleSourcePinningRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformModuleSourcePinningRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformModuleSourcePinningRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether registry modules declare `version` and git sources pin `ref` to a tag or a commit SHA
+func (r *TerraformModuleSourcePinningRule) Check(runner tflint.Runner) error {
+	config := terraformModuleSourcePinningRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	walker := &Walker{
+		Blocks: []BlockVisitor{{
+			Type:     "module",
+			TopLevel: true,
+			Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+				return r.checkModule(ctx.Runner, block, config)
+			},
+		}},
+	}
+	return walker.Walk(runner)
+}
+
+func (r *TerraformModuleSourcePinningRule) checkModule(runner tflint.Runner, block *hclsyntax.Block, config terraformModuleSourcePinningRuleConfig) error {
+	name := block.Labels[0]
+	attr, ok := block.Body.Attributes["source"]
+	if !ok {
+		return nil
+	}
+	v, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !v.IsKnown() || v.IsNull() || v.Type() != cty.String {
+		logger.Debug(fmt.Sprintf("skip checking source of module `%s` since it's not a literal string", name))
+		return nil
+	}
+	source := v.AsString()
+	if r.allowed(source, config.AllowedUnpinnedSources) {
+		return nil
+	}
+	switch classifyModuleSource(source) {
+	case moduleSourceRegistry:
+		if _, ok := block.Body.Attributes["version"]; !ok {
+			return runner.EmitIssue(r, fmt.Sprintf("registry module `%s` should declare `version`", name), block.DefRange())
+		}
+	case moduleSourceGit:
+		ref := gitSourceRef(source)
+		if ref == "" {
+			return runner.EmitIssue(r, fmt.Sprintf("git source of module `%s` should pin `ref` to a tag or a commit SHA", name), attr.Expr.Range())
+		}
+		if !r.pinnedRef(ref) {
+			return runner.EmitIssue(r, fmt.Sprintf("git source of module `%s` pins `ref` to `%s` which looks like a branch, use a tag or a commit SHA", name, ref), attr.Expr.Range())
+		}
+	}
+	return nil
+}
+
+// allowed checks whether the source is in the allowlist, an entry ending with `*` matches the sources starting with the text before it
+func (r *TerraformModuleSourcePinningRule) allowed(source string, allowlist []string) bool {
+	for _, entry := range allowlist {
+		if entry == source {
+			return true
+		}
+		if prefix, isPrefix := strings.CutSuffix(entry, "*"); isPrefix && strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// pinnedRef checks whether the ref is a commit SHA or a version tag like `v1.2.0`
+func (r *TerraformModuleSourcePinningRule) pinnedRef(ref string) bool {
+	if commitSHA.MatchString(ref) {
+		return true
+	}
+	_, err := version.NewVersion(ref)
+	return err == nil
+}
+
+// classifyModuleSource classifies the module source address following the rules of `terraform init`
+func classifyModuleSource(source string) moduleSourceType {
+	switch {
+	case strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../"):
+		return moduleSourceLocal
+	case strings.HasPrefix(source, "git::") || strings.HasPrefix(source, "git@") ||
+		strings.HasPrefix(source, "github.com/") || strings.HasPrefix(source, "bitbucket.org/"):
+		return moduleSourceGit
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		return moduleSourceHTTP
+	}
+	address, _, _ := strings.Cut(source, "//")
+	segments := strings.Split(address, "/")
+	if len(segments) == 4 && strings.Contains(segments[0], ".") {
+		segments = segments[1:]
+	}
+	if len(segments) != 3 {
+		return moduleSourceUnknown
+	}
+	for _, segment := range segments {
+		if !registrySourceSegment.MatchString(segment) {
+			return moduleSourceUnknown
+		}
+	}
+	return moduleSourceRegistry
+}
+
+// gitSourceRef returns the `ref` query argument of a git source
+func gitSourceRef(source string) string {
+	_, query, found := strings.Cut(source, "?")
+	if !found {
+		return ""
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return ""
+	}
+	return values.Get("ref")
+}
@@ -0,0 +1,121 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformModuleSourcePinningRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. pinned sources",
+			Content: `
+module "local" {
+  source = "./modules/network"
+}
+
+module "registry" {
+  source  = "Azure/network/azurerm"
+  version = "~> 5.0"
+}
+
+module "private_registry" {
+  source  = "app.terraform.io/example/network/azurerm//modules/subnet"
+  version = "~> 1.0"
+}
+
+module "git_tag" {
+  source = "git::https://example.com/network.git//modules/subnet?ref=v1.2.0"
+}
+
+module "github_sha" {
+  source = "github.com/Azure/terraform-azurerm-network?ref=51d1b6a"
+}
+
+module "http" {
+  source = "https://example.com/network.zip"
+}
+
+module "dynamic" {
+  source = var.source
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. unpinned sources",
+			Content: `
+module "registry" {
+  source = "Azure/network/azurerm"
+}
+
+module "git_without_ref" {
+  source = "git::https://example.com/network.git"
+}
+
+module "git_branch" {
+  source = "git@github.com:Azure/terraform-azurerm-network.git?ref=main"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformModuleSourcePinningRule(),
+					Message: "registry module `registry` should declare `version`",
+				},
+				{
+					Rule:    NewTerraformModuleSourcePinningRule(),
+					Message: "git source of module `git_without_ref` should pin `ref` to a tag or a commit SHA",
+				},
+				{
+					Rule:    NewTerraformModuleSourcePinningRule(),
+					Message: "git source of module `git_branch` pins `ref` to `main` which looks like a branch, use a tag or a commit SHA",
+				},
+			},
+		},
+		{
+			Name: "3. allowed unpinned sources",
+			Config: `
+rule "terraform_module_source_pinning" {
+  enabled                  = true
+  allowed_unpinned_sources = ["Azure/network/azurerm", "git::https://example.com/*"]
+}`,
+			Content: `
+module "registry" {
+  source = "Azure/network/azurerm"
+}
+
+module "git_branch" {
+  source = "git::https://example.com/network.git?ref=develop"
+}
+
+module "other_git_branch" {
+  source = "git::https://other.example.com/network.git?ref=develop"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformModuleSourcePinningRule(),
+					Message: "git source of module `other_git_branch` pins `ref` to `develop` which looks like a branch, use a tag or a commit SHA",
+				},
+			},
+		},
+	}
+	rule := NewTerraformModuleSourcePinningRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"config.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
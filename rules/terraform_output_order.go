@@ -2,12 +2,10 @@ package rules
 
 import (
 	"fmt"
-	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"sort"
 	"strings"
 
 	"github.com/Azure/tflint-ruleset-basic-ext/project"
-	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -46,69 +44,63 @@ func (r *TerraformOutputOrderRule) Link() string {
 
 // Check checks whether the outputs are sorted in expected order
 func (r *TerraformOutputOrderRule) Check(runner tflint.Runner) error {
-	files, err := runner.GetFiles()
-	if err != nil {
-		return err
+	var outputs hclsyntax.Blocks
+	walker := &Walker{
+		IncludeOverrideFiles: true,
+		File: func(ctx *WalkContext) error {
+			outputs = nil
+			return nil
+		},
+		Blocks: []BlockVisitor{{
+			Type:     "output",
+			TopLevel: true,
+			Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+				outputs = append(outputs, block)
+				return nil
+			},
+		}},
+		AfterFile: func(ctx *WalkContext) error {
+			return r.checkOutputOrder(ctx.Runner, ctx.File, outputs)
+		},
 	}
-	for _, file := range files {
-		if subErr := r.checkOutputOrder(runner, file); subErr != nil {
-			err = multierror.Append(err, subErr)
-		}
-	}
-	return err
+	return walker.Walk(runner)
 }
 
-func (r *TerraformOutputOrderRule) checkOutputOrder(runner tflint.Runner, file *hcl.File) error {
-	body, ok := file.Body.(*hclsyntax.Body)
-	if !ok {
-		logger.Debug("skip terraform_output_order check since it's not hcl file")
-		return nil
-	}
-	blocks := body.Blocks
-	firstOutputBlockRange := r.firstOutputRange(blocks)
-	if firstOutputBlockRange == nil {
-		return nil
-	}
-	if r.sorted(blocks) {
+func (r *TerraformOutputOrderRule) checkOutputOrder(runner tflint.Runner, file *hcl.File, outputs hclsyntax.Blocks) error {
+	if len(outputs) == 0 || r.sorted(outputs) {
 		return nil
 	}
-	return r.suggestedOrder(runner, file, blocks, firstOutputBlockRange)
+	return r.suggestedOrder(runner, file, outputs)
 }
 
-func (r *TerraformOutputOrderRule) suggestedOrder(runner tflint.Runner, file *hcl.File, blocks hclsyntax.Blocks, firstOutputBlockRange *hcl.Range) error {
-	sort.Slice(blocks, func(i, j int) bool {
-		return blocks[i].Labels[0] < blocks[j].Labels[0]
+func (r *TerraformOutputOrderRule) suggestedOrder(runner tflint.Runner, file *hcl.File, outputs hclsyntax.Blocks) error {
+	sortedOutputs := make(hclsyntax.Blocks, len(outputs))
+	copy(sortedOutputs, outputs)
+	sort.SliceStable(sortedOutputs, func(i, j int) bool {
+		return sortedOutputs[i].Labels[0] < sortedOutputs[j].Labels[0]
 	})
+	var ranges, sortedRanges []hcl.Range
 	var sortedOutputHclTxts []string
-	for _, b := range blocks {
+	for i, b := range sortedOutputs {
+		ranges = append(ranges, outputs[i].Range())
+		sortedRanges = append(sortedRanges, b.Range())
 		sortedOutputHclTxts = append(sortedOutputHclTxts, string(b.Range().SliceBytes(file.Bytes)))
 	}
 	sortedOutputHclBytes := hclwrite.Format([]byte(strings.Join(sortedOutputHclTxts, "\n\n")))
-	return runner.EmitIssue(
+	return runner.EmitIssueWithFix(
 		r,
 		fmt.Sprintf("Recommended output order:\n%s", sortedOutputHclBytes),
-		*firstOutputBlockRange,
+		outputs[0].DefRange(),
+		func(f tflint.Fixer) error {
+			return ReorderWithComments(f, file, ranges, sortedRanges)
+		},
 	)
 }
 
-func (r *TerraformOutputOrderRule) sorted(blocks hclsyntax.Blocks) bool {
+func (r *TerraformOutputOrderRule) sorted(outputs hclsyntax.Blocks) bool {
 	var outputNames []string
-	for _, block := range blocks {
-		switch block.Type {
-		case "output":
-			outputNames = append(outputNames, block.Labels[0])
-		}
+	for _, block := range outputs {
+		outputNames = append(outputNames, block.Labels[0])
 	}
-
 	return sort.StringsAreSorted(outputNames)
 }
-
-func (r *TerraformOutputOrderRule) firstOutputRange(blocks hclsyntax.Blocks) *hcl.Range {
-	for _, b := range blocks {
-		switch b.Type {
-		case "output":
-			return ref(b.DefRange())
-		}
-	}
-	return nil
-}
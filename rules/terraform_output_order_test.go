@@ -113,3 +113,41 @@ output "instance_ip_addr" {
 		})
 	}
 }
+
+func Test_TerraformOutputOrderRule_Fix(t *testing.T) {
+	content := `
+# License header
+
+# the ip address
+output "instance_ip_addr" {
+  value = aws_instance.server.private_ip
+}
+
+resource "null_resource" "this" {}
+
+output "db_password" {
+  value     = aws_db_instance.db.password
+  sensitive = true
+}`
+	expected := `
+# License header
+
+output "db_password" {
+  value     = aws_db_instance.db.password
+  sensitive = true
+}
+
+resource "null_resource" "this" {}
+
+# the ip address
+output "instance_ip_addr" {
+  value = aws_instance.server.private_ip
+}`
+
+	rule := NewTerraformOutputOrderRule()
+	runner := helper.TestRunner(t, map[string]string{"config.tf": content})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertChanges(t, map[string]string{"config.tf": expected}, runner.Changes())
+}
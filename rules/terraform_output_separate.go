@@ -42,7 +42,7 @@ func (r *TerraformOutputSeparateRule) Link() string {
 // Check checks whether the variables are separated from other types of blocks
 func (r *TerraformOutputSeparateRule) Check(runner tflint.Runner) error {
 
-	files, err := runner.GetFiles()
+	files, err := lintedFiles(runner)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,174 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ tflint.Rule = &TerraformProviderVersionConstraintStyleRule{}
+
+// TerraformProviderVersionConstraintStyleRule checks whether the version constraints of terraform and providers follow the house style
+type TerraformProviderVersionConstraintStyleRule struct {
+	tflint.DefaultRule
+}
+
+type terraformProviderVersionConstraintStyleRuleConfig struct {
+	AllowExactPins      bool `hclext:"allow_exact_pins,optional"`
+	AllowUnbounded      bool `hclext:"allow_unbounded,optional"`
+	AllowNonPessimistic bool `hclext:"allow_non_pessimistic,optional"`
+}
+
+// versionConstraintOperators are ordered so that the longer operators are matched first
+var versionConstraintOperators = []string{"~>", ">=", "<=", "!=", ">", "<", "="}
+
+// NewTerraformProviderVersionConstraintStyleRule returns a new rule
+func NewTerraformProviderVersionConstraintStyleRule() *TerraformProviderVersionConstraintStyleRule {
+	return &TerraformProviderVersionConstraintStyleRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformProviderVersionConstraintStyleRule) Name() string {
+	return "terraform_provider_version_constraint_style"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformProviderVersionConstraintStyleRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformProviderVersionConstraintStyleRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *TerraformProviderVersionConstraintStyleRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks the style of `required_version` and the `version` of providers in `required_providers`
+func (r *TerraformProviderVersionConstraintStyleRule) Check(runner tflint.Runner) error {
+	config := terraformProviderVersionConstraintStyleRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	walker := &Walker{
+		Attribute: func(ctx *WalkContext, attr *hclsyntax.Attribute) error {
+			return r.checkAttribute(ctx, attr, config)
+		},
+	}
+	return walker.Walk(runner)
+}
+
+// checkAttribute checks `required_version` in `terraform` and the entries of `required_providers`
+func (r *TerraformProviderVersionConstraintStyleRule) checkAttribute(ctx *WalkContext, attr *hclsyntax.Attribute, config terraformProviderVersionConstraintStyleRuleConfig) error {
+	switch len(ctx.Parents) {
+	case 1:
+		if ctx.Parent().Type == "terraform" && attr.Name == "required_version" {
+			return r.checkConstraint(ctx.Runner, "`required_version`", attr.Expr, config)
+		}
+	case 2:
+		if ctx.TopLevelBlock().Type != "terraform" || ctx.Parent().Type != "required_providers" {
+			return nil
+		}
+		if expr := r.providerVersionExpr(attr); expr != nil {
+			return r.checkConstraint(ctx.Runner, fmt.Sprintf("version of provider `%s`", attr.Name), expr, config)
+		}
+	}
+	return nil
+}
+
+// providerVersionExpr returns the `version` of an entry in `required_providers`, the legacy syntax `name = "<version>"` is supported
+func (r *TerraformProviderVersionConstraintStyleRule) providerVersionExpr(entry *hclsyntax.Attribute) hclsyntax.Expression {
+	object, ok := entry.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return entry.Expr
+	}
+	for _, item := range object.Items {
+		if hcl.ExprAsKeyword(item.KeyExpr) == "version" {
+			return item.ValueExpr
+		}
+	}
+	return nil
+}
+
+func (r *TerraformProviderVersionConstraintStyleRule) checkConstraint(runner tflint.Runner, subject string, expr hclsyntax.Expression, config terraformProviderVersionConstraintStyleRuleConfig) error {
+	v, diags := expr.Value(nil)
+	if diags.HasErrors() || !v.IsKnown() || v.IsNull() || v.Type() != cty.String {
+		logger.Debug(fmt.Sprintf("skip checking %s since it's not a literal string", subject))
+		return nil
+	}
+	constraint := v.AsString()
+	if _, parseErr := version.NewConstraint(constraint); parseErr != nil {
+		return runner.EmitIssue(r, fmt.Sprintf("%s `%s` is malformed: %s", subject, constraint, parseErr), r.literalRange(expr))
+	}
+	if msg := r.styleProblem(constraint, config); msg != "" {
+		return runner.EmitIssue(r, fmt.Sprintf("%s `%s` %s", subject, constraint, msg), r.literalRange(expr))
+	}
+	return nil
+}
+
+// styleProblem returns the most specific style problem of a valid constraint, or an empty string if there is none
+func (r *TerraformProviderVersionConstraintStyleRule) styleProblem(constraint string, config terraformProviderVersionConstraintStyleRuleConfig) string {
+	hasUpperBound := false
+	hasLowerBound := false
+	var pessimisticVersions []string
+	for _, c := range strings.Split(constraint, ",") {
+		operator, v := r.splitOperator(strings.TrimSpace(c))
+		switch operator {
+		case "=":
+			if !config.AllowExactPins {
+				return "pins an exact version, use `~>` instead"
+			}
+			hasUpperBound = true
+		case "~>":
+			hasUpperBound = true
+			pessimisticVersions = append(pessimisticVersions, v)
+		case "<", "<=":
+			hasUpperBound = true
+		case ">", ">=":
+			hasLowerBound = true
+		}
+	}
+	if hasLowerBound && !hasUpperBound && !config.AllowUnbounded {
+		return "has no upper bound, use `~>` instead"
+	}
+	if config.AllowNonPessimistic {
+		return ""
+	}
+	if len(pessimisticVersions) == 0 {
+		return "should use the pessimistic operator `~>`"
+	}
+	for _, v := range pessimisticVersions {
+		if !strings.Contains(v, ".") {
+			return fmt.Sprintf("should specify a minor version, e.g. `~> %s.0`", v)
+		}
+	}
+	return ""
+}
+
+// splitOperator splits a single constraint like `~> 3.0` into its operator and version, a bare version is an exact pin
+func (r *TerraformProviderVersionConstraintStyleRule) splitOperator(constraint string) (string, string) {
+	for _, operator := range versionConstraintOperators {
+		if strings.HasPrefix(constraint, operator) {
+			return operator, strings.TrimSpace(strings.TrimPrefix(constraint, operator))
+		}
+	}
+	return "=", constraint
+}
+
+// literalRange returns the range of the string literal without quotes, or the range of the expression if it's not a plain string
+func (r *TerraformProviderVersionConstraintStyleRule) literalRange(expr hclsyntax.Expression) hcl.Range {
+	if tmpl, ok := expr.(*hclsyntax.TemplateExpr); ok && len(tmpl.Parts) == 1 {
+		return tmpl.Parts[0].Range()
+	}
+	return expr.Range()
+}
@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformProviderVersionConstraintStyleRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. pessimistic constraints",
+			Content: `
+terraform {
+  required_version = "~> 1.3"
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.0.2"
+    }
+    random = "~> 3.4"
+    local = {
+      source  = "hashicorp/local"
+      version = ">= 2.1, ~> 2.1"
+    }
+    null = {
+      source = "hashicorp/null"
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. violations",
+			Content: `
+terraform {
+  required_version = ">= 1.3"
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "3.0.2"
+    }
+    random = "= 3.4.0"
+    local = {
+      source  = "hashicorp/local"
+      version = ">= 2.1, < 3.0"
+    }
+    null = {
+      source  = "hashicorp/null"
+      version = "~> 3"
+    }
+    tls = {
+      source  = "hashicorp/tls"
+      version = "~> x.y"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformProviderVersionConstraintStyleRule(),
+					Message: "`required_version` `>= 1.3` has no upper bound, use `~>` instead",
+				},
+				{
+					Rule:    NewTerraformProviderVersionConstraintStyleRule(),
+					Message: "version of provider `azurerm` `3.0.2` pins an exact version, use `~>` instead",
+				},
+				{
+					Rule:    NewTerraformProviderVersionConstraintStyleRule(),
+					Message: "version of provider `random` `= 3.4.0` pins an exact version, use `~>` instead",
+				},
+				{
+					Rule:    NewTerraformProviderVersionConstraintStyleRule(),
+					Message: "version of provider `local` `>= 2.1, < 3.0` should use the pessimistic operator `~>`",
+				},
+				{
+					Rule:    NewTerraformProviderVersionConstraintStyleRule(),
+					Message: "version of provider `null` `~> 3` should specify a minor version, e.g. `~> 3.0`",
+				},
+				{
+					Rule:    NewTerraformProviderVersionConstraintStyleRule(),
+					Message: "version of provider `tls` `~> x.y` is malformed: Malformed constraint: ~> x.y",
+				},
+			},
+		},
+		{
+			Name: "3. relaxed style",
+			Config: `
+rule "terraform_provider_version_constraint_style" {
+  enabled               = true
+  allow_exact_pins      = true
+  allow_unbounded       = true
+  allow_non_pessimistic = true
+}`,
+			Content: `
+terraform {
+  required_version = ">= 1.3"
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "3.0.2"
+    }
+    local = {
+      source  = "hashicorp/local"
+      version = ">= 2.1, < 3.0"
+    }
+    null = {
+      source  = "hashicorp/null"
+      version = "~> 3"
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+	rule := NewTerraformProviderVersionConstraintStyleRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"config.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformProviderVersionConstraintStyleRule_MalformedRange(t *testing.T) {
+	rule := NewTerraformProviderVersionConstraintStyleRule()
+	runner := helper.TestRunner(t, map[string]string{"config.tf": `
+terraform {
+  required_version = "~> 1.x"
+}`})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "`required_version` `~> 1.x` is malformed: Malformed constraint: ~> 1.x",
+			Range: hcl.Range{
+				Filename: "config.tf",
+				Start:    hcl.Pos{Line: 3, Column: 23},
+				End:      hcl.Pos{Line: 3, Column: 29},
+			},
+		},
+	}, runner.Issues)
+}
@@ -0,0 +1,164 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ tflint.Rule = &TerraformRequiredProvidersCompletenessRule{}
+
+// TerraformRequiredProvidersCompletenessRule checks whether `required_providers` declares exactly the providers used in the module
+type TerraformRequiredProvidersCompletenessRule struct {
+	tflint.DefaultRule
+}
+
+// NewTerraformRequiredProvidersCompletenessRule returns a new rule
+func NewTerraformRequiredProvidersCompletenessRule() *TerraformRequiredProvidersCompletenessRule {
+	return &TerraformRequiredProvidersCompletenessRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformRequiredProvidersCompletenessRule) Name() string {
+	return "terraform_required_providers_completeness"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformRequiredProvidersCompletenessRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformRequiredProvidersCompletenessRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformRequiredProvidersCompletenessRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether every used provider is declared with `source` and `version`, and every declared provider is used
+func (r *TerraformRequiredProvidersCompletenessRule) Check(runner tflint.Runner) error {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	// usages count in every file of the module, only the ones in linted files are reported
+	usages := providerUsages(files)
+	declared, err := r.declaredProviders(runner)
+	if err != nil {
+		return err
+	}
+	walker := &Walker{
+		Blocks: []BlockVisitor{{
+			Type:     "terraform",
+			TopLevel: true,
+			Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+				return r.checkRequiredProviders(ctx.Runner, block, usages)
+			},
+		}},
+	}
+	err = walker.Walk(runner)
+
+	var usedNames []string
+	for name := range usages {
+		usedNames = append(usedNames, name)
+	}
+	sort.Strings(usedNames)
+	for _, name := range usedNames {
+		if declared[name] || !isLintedFile(usages[name].Filename) {
+			continue
+		}
+		if subErr := runner.EmitIssue(r, fmt.Sprintf("provider `%s` is used but not declared in `required_providers`", name), usages[name]); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+// declaredProviders returns the names of the providers declared in `required_providers` of the module, including
+// the JSON files and the files which aren't linted, the declarations in override files don't count
+func (r *TerraformRequiredProvidersCompletenessRule) declaredProviders(runner tflint.Runner) (map[string]bool, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "terraform",
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "required_providers",
+							Body: &hclext.BodySchema{Mode: hclext.SchemaJustAttributesMode},
+						},
+					},
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return nil, err
+	}
+	declared := make(map[string]bool)
+	for _, block := range content.Blocks {
+		for _, requiredProviders := range block.Body.Blocks {
+			for name, attr := range requiredProviders.Body.Attributes {
+				if !isOverrideTfFile(attr.Range.Filename) {
+					declared[name] = true
+				}
+			}
+		}
+	}
+	return declared, nil
+}
+
+func (r *TerraformRequiredProvidersCompletenessRule) checkRequiredProviders(runner tflint.Runner, block *hclsyntax.Block, usages map[string]hcl.Range) error {
+	var err error
+	for _, nestedBlock := range block.Body.Blocks {
+		if nestedBlock.Type != "required_providers" {
+			continue
+		}
+		for _, entry := range attributesByLines(nestedBlock.Body.Attributes) {
+			for _, msg := range r.entryProblems(entry, usages) {
+				if subErr := runner.EmitIssue(r, msg, entry.NameRange); subErr != nil {
+					err = multierror.Append(err, subErr)
+				}
+			}
+		}
+	}
+	return err
+}
+
+func (r *TerraformRequiredProvidersCompletenessRule) entryProblems(entry *hclsyntax.Attribute, usages map[string]hcl.Range) []string {
+	var problems []string
+	if _, used := usages[entry.Name]; !used {
+		problems = append(problems, fmt.Sprintf("provider `%s` is declared in `required_providers` but not used", entry.Name))
+	}
+	keys := make(map[string]bool)
+	if object, ok := entry.Expr.(*hclsyntax.ObjectConsExpr); ok {
+		for _, item := range object.Items {
+			if key := hcl.ExprAsKeyword(item.KeyExpr); key != "" {
+				keys[key] = true
+				continue
+			}
+			if key, diags := item.KeyExpr.Value(nil); !diags.HasErrors() && key.Type() == cty.String && key.IsKnown() {
+				keys[key.AsString()] = true
+			}
+		}
+	} else {
+		// the legacy syntax `name = "<version>"` only declares the version
+		keys["version"] = true
+	}
+	for _, key := range []string{"source", "version"} {
+		if !keys[key] {
+			problems = append(problems, fmt.Sprintf("provider `%s` in `required_providers` should declare `%s`", entry.Name, key))
+		}
+	}
+	return problems
+}
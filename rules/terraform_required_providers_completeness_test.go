@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformRequiredProvidersCompletenessRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. complete declaration",
+			Files: map[string]string{
+				"versions.tf": `
+terraform {
+  required_providers {
+    azapi = {
+      source  = "Azure/azapi"
+      version = "~> 1.0"
+    }
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.0"
+    }
+    random = {
+      "source"  = "hashicorp/random"
+      "version" = "~> 3.4"
+    }
+  }
+}`,
+				"main.tf": `
+provider "azurerm" {
+  features {}
+}
+
+resource "random_string" "suffix" {
+  length = 6
+}
+
+resource "terraform_data" "replacement" {}
+
+module "network" {
+  source = "./network"
+  providers = {
+    azurerm = azurerm
+    azapi   = azapi.west
+  }
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. incomplete declaration",
+			Files: map[string]string{
+				"versions.tf": `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+    azurerm = {
+      source = "hashicorp/azurerm"
+    }
+    random = "~> 3.4"
+  }
+}`,
+				"main.tf": `
+resource "azurerm_resource_group" "rg" {
+  name     = "example"
+  location = "westus"
+}
+
+resource "random_string" "suffix" {
+  length = 6
+}
+
+data "google_client_config" "current" {
+  provider = google-beta
+}
+
+data "azapi_resource" "vnet" {
+  name = "example"
+}`,
+				"main_override.tf": `
+terraform {
+  required_providers {
+    azapi = {
+      source = "Azure/azapi"
+    }
+  }
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredProvidersCompletenessRule(),
+					Message: "provider `aws` is declared in `required_providers` but not used",
+				},
+				{
+					Rule:    NewTerraformRequiredProvidersCompletenessRule(),
+					Message: "provider `azurerm` in `required_providers` should declare `version`",
+				},
+				{
+					Rule:    NewTerraformRequiredProvidersCompletenessRule(),
+					Message: "provider `random` in `required_providers` should declare `source`",
+				},
+				{
+					Rule:    NewTerraformRequiredProvidersCompletenessRule(),
+					Message: "provider `azapi` is used but not declared in `required_providers`",
+				},
+				{
+					Rule:    NewTerraformRequiredProvidersCompletenessRule(),
+					Message: "provider `google-beta` is used but not declared in `required_providers`",
+				},
+			},
+		},
+	}
+	rule := NewTerraformRequiredProvidersCompletenessRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -6,9 +6,10 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
-	"github.com/terraform-linters/tflint-plugin-sdk/helper"
-	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -28,8 +29,30 @@ func (r *TerraformRequiredProvidersDeclarationRule) Severity() tflint.Severity {
 	return tflint.NOTICE
 }
 
+// Check checks whether the module declares `required_providers`, the missing block is reported once for the module
 func (r *TerraformRequiredProvidersDeclarationRule) Check(runner tflint.Runner) error {
-	return ForFiles(runner, r.CheckFile)
+	var terraformBlocks []*hclsyntax.Block
+	walker := &Walker{
+		Blocks: []BlockVisitor{{
+			Type:     "terraform",
+			TopLevel: true,
+			Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+				terraformBlocks = append(terraformBlocks, block)
+				return r.checkBlock(ctx.Runner, block)
+			},
+		}},
+	}
+	if err := walker.Walk(runner); err != nil {
+		return err
+	}
+	if len(terraformBlocks) == 0 {
+		return nil
+	}
+	declared, err := r.isRequiredProvidersDeclared(runner)
+	if err != nil || declared {
+		return err
+	}
+	return r.reportMissingRequiredProviders(runner, r.skeletonTarget(terraformBlocks))
 }
 
 // NewTerraformRequiredProvidersDeclarationRule returns a new rule
@@ -42,102 +65,189 @@ func (r *TerraformRequiredProvidersDeclarationRule) Name() string {
 	return "terraform_required_providers_declaration"
 }
 
-func (r *TerraformRequiredProvidersDeclarationRule) CheckFile(runner tflint.Runner, file *hcl.File) error {
+func (r *TerraformRequiredProvidersDeclarationRule) checkBlock(runner tflint.Runner, block *hclsyntax.Block) error {
 	var err error
-	body, ok := file.Body.(*hclsyntax.Body)
-	if !ok {
-		logger.Debug("skip terraform_required_providers_declaration check since it's not hcl file")
-		return nil
+	for _, nestedBlock := range block.Body.Blocks {
+		if nestedBlock.Type != "required_providers" {
+			continue
+		}
+		if subErr := r.checkRequiredProvidersArgOrder(runner, nestedBlock); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
 	}
-	filename := body.Range().Filename
-	if isOverrideTfFile(filename) {
-		logger.Debug("skip terraform_required_version_declaration check since it's override file")
-		return nil
+	return err
+}
+
+// isRequiredProvidersDeclared checks whether any `terraform` block of the module declares `required_providers`,
+// including the blocks in JSON files and in the files which aren't linted
+func (r *TerraformRequiredProvidersDeclarationRule) isRequiredProvidersDeclared(runner tflint.Runner) (bool, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "terraform",
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{{Type: "required_providers"}},
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return false, err
 	}
-	blocks := body.Blocks
-	for _, block := range blocks {
-		switch block.Type {
-		case "terraform":
-			if subErr := r.checkBlock(runner, block); subErr != nil {
-				err = multierror.Append(err, subErr)
-			}
+	for _, block := range content.Blocks {
+		if len(block.Body.Blocks) > 0 {
+			return true, nil
 		}
 	}
-	return err
+	return false, nil
 }
 
-func (r *TerraformRequiredProvidersDeclarationRule) checkBlock(runner tflint.Runner, block *hclsyntax.Block) error {
-	isRequiredProvidersDeclared := false
-	var err error
-	for _, nestedBlock := range block.Body.Blocks {
-		switch nestedBlock.Type {
-		case "required_providers":
-			isRequiredProvidersDeclared = true
-			err = multierror.Append(err, r.checkRequiredProvidersArgOrder(runner, nestedBlock))
+// skeletonTarget returns the `terraform` block to insert `required_providers` into, the block in `versions.tf` is
+// preferred, otherwise the first one
+func (r *TerraformRequiredProvidersDeclarationRule) skeletonTarget(blocks []*hclsyntax.Block) *hclsyntax.Block {
+	for _, block := range blocks {
+		if filepath.Base(block.Range().Filename) == "versions.tf" {
+			return block
 		}
 	}
-	if isRequiredProvidersDeclared {
-		return nil
+	return blocks[0]
+}
+
+func (r *TerraformRequiredProvidersDeclarationRule) reportMissingRequiredProviders(runner tflint.Runner, block *hclsyntax.Block) error {
+	providerNames, err := r.usedProviderNames(runner)
+	if err != nil {
+		return err
 	}
-	return runner.EmitIssue(
+	return runner.EmitIssueWithFix(
 		r,
 		"The `required_providers` field should be declared in `terraform` block",
 		block.DefRange(),
+		func(f tflint.Fixer) error {
+			return f.InsertTextBefore(block.CloseBraceRange, fmt.Sprintf("\n%s\n", r.requiredProvidersSkeleton(providerNames)))
+		},
 	)
 }
 
+// providerSources are the sources of the well-known providers, the source of other providers is left for the user to fill in
+var providerSources = map[string]string{
+	"archive":    "hashicorp/archive",
+	"aws":        "hashicorp/aws",
+	"azapi":      "Azure/azapi",
+	"azuread":    "hashicorp/azuread",
+	"azurerm":    "hashicorp/azurerm",
+	"external":   "hashicorp/external",
+	"google":     "hashicorp/google",
+	"helm":       "hashicorp/helm",
+	"http":       "hashicorp/http",
+	"kubernetes": "hashicorp/kubernetes",
+	"local":      "hashicorp/local",
+	"modtm":      "Azure/modtm",
+	"null":       "hashicorp/null",
+	"random":     "hashicorp/random",
+	"time":       "hashicorp/time",
+	"tls":        "hashicorp/tls",
+}
+
+// usedProviderNames returns the sorted local names of the providers used in the module
+func (r *TerraformRequiredProvidersDeclarationRule) usedProviderNames(runner tflint.Runner) ([]string, error) {
+	files, err := lintedFiles(runner)
+	if err != nil {
+		return nil, err
+	}
+	var providerNames []string
+	for name := range providerUsages(files) {
+		providerNames = append(providerNames, name)
+	}
+	sort.Strings(providerNames)
+	return providerNames, nil
+}
+
+func (r *TerraformRequiredProvidersDeclarationRule) requiredProvidersSkeleton(providerNames []string) string {
+	if len(providerNames) == 0 {
+		return "required_providers {}"
+	}
+	var providers []string
+	for _, name := range providerNames {
+		source, ok := providerSources[name]
+		if !ok {
+			providers = append(providers, fmt.Sprintf("%s = {}", name))
+			continue
+		}
+		providers = append(providers, fmt.Sprintf("%s = {\nsource = %q\n}", name, source))
+	}
+	return string(hclwrite.Format([]byte(fmt.Sprintf("required_providers {\n%s\n}", strings.Join(providers, "\n")))))
+}
+
 func (r *TerraformRequiredProvidersDeclarationRule) checkRequiredProvidersArgOrder(runner tflint.Runner, providerBlock *hclsyntax.Block) error {
-	file, _ := runner.GetFile(providerBlock.Range().Filename)
+	file, err := runner.GetFile(providerBlock.Range().Filename)
+	if err != nil {
+		return err
+	}
 	var providerNames []string
 	providerParamTxts := make(map[string]string)
-	providerParamIssues := helper.Issues{}
-	providers := providerBlock.Body.Attributes
-	for _, config := range attributesByLines(providers) {
+	var unsortedProviders []*hclsyntax.Attribute
+	providers := attributesByLines(providerBlock.Body.Attributes)
+	sort.SliceStable(providers, func(i, j int) bool {
+		return providers[i].SrcRange.Start.Byte < providers[j].SrcRange.Start.Byte
+	})
+	for _, config := range providers {
 		sortedMap, sorted := PrintSortedAttrTxt(file.Bytes, config)
 		name := config.Name
 		providerParamTxts[name] = sortedMap
 		providerNames = append(providerNames, name)
 		if !sorted {
-			providerParamIssues = append(providerParamIssues, &helper.Issue{
-				Rule:    r,
-				Message: fmt.Sprintf("Parameters of provider `%s` are expected to be sorted as follows:\n%s", name, sortedMap),
-				Range:   config.NameRange,
-			})
+			unsortedProviders = append(unsortedProviders, config)
 		}
 	}
-	sort.Slice(providerNames, func(x, y int) bool {
-		providerX := providers[providerNames[x]]
-		providerY := providers[providerNames[y]]
-		if providerX.Range().Start.Line == providerY.Range().Start.Line {
-			return providerX.Range().Start.Column < providerY.Range().Start.Column
-		}
-		return providerX.Range().Start.Line < providerY.Range().Start.Line
-	})
 	if !sort.StringsAreSorted(providerNames) {
-		sort.Strings(providerNames)
+		sortedProviders := slices.Clone(providers)
+		sort.SliceStable(sortedProviders, func(i, j int) bool {
+			return sortedProviders[i].Name < sortedProviders[j].Name
+		})
+		var ranges, sortedRanges []hcl.Range
 		var sortedProviderParamTxts []string
-		for _, providerName := range providerNames {
-			sortedProviderParamTxts = append(sortedProviderParamTxts, providerParamTxts[providerName])
-		}
-		sortedProviderParamTxt := strings.Join(sortedProviderParamTxts, "\n")
-		var sortedRequiredProviderTxt string
-		if RemoveSpaceAndLine(sortedProviderParamTxt) == "" {
-			sortedRequiredProviderTxt = fmt.Sprintf("%s {}", providerBlock.Type)
-		} else {
-			sortedRequiredProviderTxt = fmt.Sprintf("%s {\n%s\n}", providerBlock.Type, sortedProviderParamTxt)
+		for i, provider := range sortedProviders {
+			ranges = append(ranges, providers[i].SrcRange)
+			sortedRanges = append(sortedRanges, provider.SrcRange)
+			sortedProviderParamTxts = append(sortedProviderParamTxts, providerParamTxts[provider.Name])
 		}
-		sortedRequiredProviderTxt = string(hclwrite.Format([]byte(sortedRequiredProviderTxt)))
-		return runner.EmitIssue(
+		sortedRequiredProviderTxt := string(hclwrite.Format([]byte(fmt.Sprintf("%s {\n%s\n}", providerBlock.Type, strings.Join(sortedProviderParamTxts, "\n")))))
+		return runner.EmitIssueWithFix(
 			r,
 			fmt.Sprintf("The arguments of `required_providers` are expected to be sorted as follows:\n%s", sortedRequiredProviderTxt),
 			providerBlock.DefRange(),
+			func(f tflint.Fixer) error {
+				return ReorderWithComments(f, file, ranges, sortedRanges)
+			},
 		)
 	}
-	var err error
-	for _, issue := range providerParamIssues {
-		if subErr := runner.EmitIssue(issue.Rule, issue.Message, issue.Range); subErr != nil {
+	for _, config := range unsortedProviders {
+		items := config.Expr.(*hclsyntax.ObjectConsExpr).Items
+		sortedItems := slices.Clone(items)
+		sort.SliceStable(sortedItems, func(i, j int) bool {
+			return exprText(file, sortedItems[i].KeyExpr) < exprText(file, sortedItems[j].KeyExpr)
+		})
+		var ranges, sortedRanges []hcl.Range
+		for i, item := range sortedItems {
+			ranges = append(ranges, objectItemRange(items[i]))
+			sortedRanges = append(sortedRanges, objectItemRange(item))
+		}
+		subErr := runner.EmitIssueWithFix(
+			r,
+			fmt.Sprintf("Parameters of provider `%s` are expected to be sorted as follows:\n%s", config.Name, providerParamTxts[config.Name]),
+			config.NameRange,
+			func(f tflint.Fixer) error {
+				return ReorderWithComments(f, file, ranges, sortedRanges)
+			},
+		)
+		if subErr != nil {
 			err = multierror.Append(err, subErr)
 		}
 	}
 	return err
 }
+
+// objectItemRange returns the range of an object item from its key to its value
+func objectItemRange(item hclsyntax.ObjectConsItem) hcl.Range {
+	return hcl.RangeBetween(item.KeyExpr.Range(), item.ValueExpr.Range())
+}
@@ -247,3 +247,267 @@ terraform {
 		})
 	}
 }
+
+func Test_TerraformRequiredProvidersDeclaration_MultipleFiles(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected map[string]string
+	}{
+		{
+			Name: "1. required_providers declared in another file",
+			Files: map[string]string{
+				"backend.tf": `
+terraform {
+  backend "azurerm" {}
+}`,
+				"versions.tf": `
+terraform {
+  required_providers {}
+}`,
+			},
+			Expected: map[string]string{},
+		},
+		{
+			Name: "2. required_providers declared in a JSON file",
+			Files: map[string]string{
+				"backend.tf": `
+terraform {
+  backend "azurerm" {}
+}`,
+				"versions.tf.json": `{"terraform": {"required_providers": {}}}`,
+			},
+			Expected: map[string]string{},
+		},
+		{
+			Name: "3. only one skeleton is inserted",
+			Files: map[string]string{
+				"backend.tf": `
+terraform {
+  backend "azurerm" {}
+}`,
+				"versions.tf": `
+terraform {
+  required_version = "~> 1.3"
+}`,
+			},
+			Expected: map[string]string{"versions.tf": `
+terraform {
+  required_version = "~> 1.3"
+
+  required_providers {}
+}`},
+		},
+	}
+	rule := NewTerraformRequiredProvidersDeclarationRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+			require.NoError(t, rule.Check(runner))
+			require.Len(t, runner.Issues, len(tc.Expected))
+			helper.AssertChanges(t, tc.Expected, runner.Changes())
+		})
+	}
+}
+
+func Test_TerraformRequiredProvidersDeclaration_Fix(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected string
+	}{
+		{
+			Name: "1. sort providers",
+			Content: `
+terraform {
+  required_version = "~> 0.12.29"
+  required_providers {
+    azurerm = {
+      version = "~> 3.0.2"
+      source  = "hashicorp/azurerm"
+    }
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 2.7.0"
+    }
+  }
+}`,
+			Expected: `
+terraform {
+  required_version = "~> 0.12.29"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 2.7.0"
+    }
+    azurerm = {
+      version = "~> 3.0.2"
+      source  = "hashicorp/azurerm"
+    }
+  }
+}`,
+		},
+		{
+			Name: "2. sort parameters of providers",
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      version = ">= 2.7.0"
+      source  = "hashicorp/aws"
+    }
+    azurerm = {
+      version = "~> 3.0.2"
+      source  = "hashicorp/azurerm"
+    }
+  }
+}`,
+			Expected: `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 2.7.0"
+    }
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.0.2"
+    }
+  }
+}`,
+		},
+		{
+			Name: "3. insert missing required_providers",
+			Content: `
+terraform {
+  required_version = "~> 1.3"
+}
+
+resource "azurerm_resource_group" "rg" {
+  provider = azurerm.west
+  name     = "example"
+  location = "westus"
+}
+
+resource "random_string" "suffix" {
+  length = 6
+}
+
+resource "terraform_data" "replacement" {}
+
+resource "acme_widget" "this" {}
+
+data "azapi_resource" "vnet" {
+  provider = azapi
+  name     = "example"
+}`,
+			Expected: `
+terraform {
+  required_version = "~> 1.3"
+
+  required_providers {
+    acme = {}
+    azapi = {
+      source = "Azure/azapi"
+    }
+    azurerm = {
+      source = "hashicorp/azurerm"
+    }
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}
+
+resource "azurerm_resource_group" "rg" {
+  provider = azurerm.west
+  name     = "example"
+  location = "westus"
+}
+
+resource "random_string" "suffix" {
+  length = 6
+}
+
+resource "terraform_data" "replacement" {}
+
+resource "acme_widget" "this" {}
+
+data "azapi_resource" "vnet" {
+  provider = azapi
+  name     = "example"
+}`,
+		},
+		{
+			Name: "4. insert empty required_providers",
+			Content: `
+terraform {}`,
+			Expected: `
+terraform {
+  required_providers {}
+}`,
+		},
+		{
+			Name: "5. keep comments when sorting",
+			Content: `
+terraform {
+  required_providers {
+    # the Azure provider
+    azurerm = {
+      version = "~> 3.0.2" # pinned for the AKS module
+      source  = "hashicorp/azurerm"
+    }
+    aws = { source = "hashicorp/aws" } // only for the S3 backend
+  }
+}`,
+			Expected: `
+terraform {
+  required_providers {
+    aws = { source = "hashicorp/aws" } // only for the S3 backend
+    # the Azure provider
+    azurerm = {
+      version = "~> 3.0.2" # pinned for the AKS module
+      source  = "hashicorp/azurerm"
+    }
+  }
+}`,
+		},
+		{
+			Name: "6. keep comments when sorting parameters",
+			Content: `
+terraform {
+  required_providers {
+    aws = { version = ">= 2.7.0", source = "hashicorp/aws" }
+    azurerm = {
+      # pinned for the AKS module
+      version = "~> 3.0.2"
+      source  = "hashicorp/azurerm" # the Azure provider
+    }
+  }
+}`,
+			Expected: `
+terraform {
+  required_providers {
+    aws = { source = "hashicorp/aws", version = ">= 2.7.0" }
+    azurerm = {
+      source = "hashicorp/azurerm" # the Azure provider
+      # pinned for the AKS module
+      version = "~> 3.0.2"
+    }
+  }
+}`,
+		},
+	}
+	rule := NewTerraformRequiredProvidersDeclarationRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			helper.AssertChanges(t, map[string]string{"config.tf": tc.Expected}, runner.Changes())
+		})
+	}
+}
@@ -15,6 +15,15 @@ type TerraformResourceDataArgLayoutRule struct {
 	tflint.DefaultRule
 }
 
+type terraformResourceDataArgLayoutRuleConfig struct {
+	HeadMetaArgs       map[string]int `hclext:"head_meta_args,optional"`
+	TailMetaArgs       map[string]int `hclext:"tail_meta_args,optional"`
+	ArgOrderProfile    string         `hclext:"arg_order_profile,optional"`
+	ArgOrderSchemaFile string         `hclext:"arg_order_schema_file,optional"`
+}
+
+const requiredFirstArgOrderProfile = "required_first"
+
 // NewTerraformResourceDataArgLayoutRule returns a new rule
 func NewTerraformResourceDataArgLayoutRule() *TerraformResourceDataArgLayoutRule {
 	return &TerraformResourceDataArgLayoutRule{}
@@ -42,12 +51,28 @@ func (r *TerraformResourceDataArgLayoutRule) Link() string {
 
 // Check checks whether the arguments/attributes in a block are sorted in azure doc Layout
 func (r *TerraformResourceDataArgLayoutRule) Check(runner tflint.Runner) error {
-	files, err := runner.GetFiles()
+	config := terraformResourceDataArgLayoutRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	layout, err := NewMetaArgLayout(config.HeadMetaArgs, config.TailMetaArgs)
+	if err != nil {
+		return err
+	}
+	profiles, err := r.argOrderProfiles(runner, config)
+	if err != nil {
+		return err
+	}
+	options := ResourceBlockOptions{
+		Layout:           layout,
+		ArgOrderProfiles: profiles,
+	}
+	files, err := lintedFiles(runner)
 	if err != nil {
 		return err
 	}
 	for _, file := range files {
-		subErr := r.visitFile(runner, file)
+		subErr := r.visitFile(runner, file, options)
 		if subErr != nil {
 			err = multierror.Append(err, subErr)
 		}
@@ -55,7 +80,22 @@ func (r *TerraformResourceDataArgLayoutRule) Check(runner tflint.Runner) error {
 	return err
 }
 
-func (r *TerraformResourceDataArgLayoutRule) visitFile(runner tflint.Runner, file *hcl.File) error {
+func (r *TerraformResourceDataArgLayoutRule) argOrderProfiles(runner tflint.Runner, config terraformResourceDataArgLayoutRuleConfig) (ArgOrderProfiles, error) {
+	switch config.ArgOrderProfile {
+	case "":
+		return nil, nil
+	case requiredFirstArgOrderProfile:
+		wd, err := runner.GetOriginalwd()
+		if err != nil {
+			return nil, err
+		}
+		return LoadArgOrderProfiles(config.ArgOrderSchemaFile, wd)
+	default:
+		return nil, fmt.Errorf("unknown arg_order_profile `%s`, expected `%s`", config.ArgOrderProfile, requiredFirstArgOrderProfile)
+	}
+}
+
+func (r *TerraformResourceDataArgLayoutRule) visitFile(runner tflint.Runner, file *hcl.File, options ResourceBlockOptions) error {
 	body, ok := file.Body.(*hclsyntax.Body)
 	if !ok {
 		logger.Debug("skip terraform_resource_data_arg_layout check since it's not hcl file")
@@ -69,13 +109,17 @@ func (r *TerraformResourceDataArgLayoutRule) visitFile(runner tflint.Runner, fil
 		switch block.Type {
 		case "resource", "data":
 			emitter := func(block Block) error {
-				return runner.EmitIssue(
+				sortedTxt := block.ToString()
+				return runner.EmitIssueWithFix(
 					r,
-					fmt.Sprintf("Arguments are expected to be arranged in following Layout:\n%s", block.ToString()),
+					fmt.Sprintf("Arguments are expected to be arranged in following Layout:\n%s", sortedTxt),
 					block.DefRange(),
+					func(f tflint.Fixer) error {
+						return f.ReplaceText(block.GetRange(), sortedTxt)
+					},
 				)
 			}
-			b := BuildResourceBlock(block, file, emitter)
+			b := BuildResourceBlockWithOptions(block, file, options, emitter)
 			if subErr := b.CheckBlock(); subErr != nil {
 				err = multierror.Append(err, subErr)
 			}
@@ -1,9 +1,14 @@
 package rules
 
 import (
-	"github.com/stretchr/testify/assert"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 )
 
@@ -500,3 +505,372 @@ resource "azurerm_virtual_network" "vnet" {
 	}
 	assert.Empty(t, runner.Issues)
 }
+
+func Test_TerraformResourceDataArgLayout_Fix(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected string
+	}{
+		{
+			Name: "rearrange block and keep comments",
+			Content: `
+resource "azurerm_virtual_network" "vnet" {
+  # name of the vnet
+  name = "myTFVnet"
+  container {
+    name = "sidecar" # the sidecar
+    # cpu of the sidecar
+    cpu  = "0.5"
+  }
+  count = 4 # four vnets
+  depends_on = [
+    azurerm_resource_group.example
+  ]
+  location = azurerm_resource_group.example.location
+  // reviewed by security team
+}`,
+			Expected: `
+resource "azurerm_virtual_network" "vnet" {
+  count = 4 # four vnets
+
+  # name of the vnet
+  name     = "myTFVnet"
+  location = azurerm_resource_group.example.location
+
+  container {
+    name = "sidecar" # the sidecar
+    # cpu of the sidecar
+    cpu = "0.5"
+  }
+
+  depends_on = [
+    azurerm_resource_group.example
+  ]
+
+  // reviewed by security team
+}`,
+		},
+		{
+			Name: "only rearrange nested block",
+			Content: `
+resource "azurerm_container_group" "example" {
+  name = "example"
+
+  container {
+    ports {
+      port = 443
+    }
+    # the image
+    image = "mcr.microsoft.com/azuredocs/aci-helloworld:latest"
+  }
+}`,
+			Expected: `
+resource "azurerm_container_group" "example" {
+  name = "example"
+
+  container {
+    # the image
+    image = "mcr.microsoft.com/azuredocs/aci-helloworld:latest"
+
+    ports {
+      port = 443
+    }
+  }
+}`,
+		},
+		{
+			Name: "keep multi-line block comments",
+			Content: `
+resource "azurerm_virtual_network" "vnet" {
+  /*
+   * name of the vnet
+   */
+  name = "myTFVnet"
+  /* four vnets,
+     one per region */
+  count = 4
+}`,
+			Expected: `
+resource "azurerm_virtual_network" "vnet" {
+  /* four vnets,
+     one per region */
+  count = 4
+
+  /*
+   * name of the vnet
+   */
+  name = "myTFVnet"
+}`,
+		},
+		{
+			Name: "keep detached comments",
+			Content: `
+resource "azurerm_virtual_network" "vnet" {
+  name = "myTFVnet"
+
+  # TODO: move to westus
+
+  count = 4
+}`,
+			Expected: `
+resource "azurerm_virtual_network" "vnet" {
+  count = 4
+
+  name = "myTFVnet"
+
+  # TODO: move to westus
+}`,
+		},
+		{
+			Name: "keep comment following opening brace",
+			Content: `
+resource "azurerm_virtual_network" "vnet" { # owned by team-a
+  name = "myTFVnet"
+  dynamic "subnet" { # one per address prefix
+    for_each = var.prefixes
+    content {}
+  }
+  count = 4
+}`,
+			Expected: `
+resource "azurerm_virtual_network" "vnet" { # owned by team-a
+  count = 4
+
+  name = "myTFVnet"
+
+  dynamic "subnet" { # one per address prefix
+    for_each = var.prefixes
+
+    content {}
+  }
+}`,
+		},
+		{
+			Name: "keep chained trailing comments",
+			Content: `
+resource "azurerm_virtual_network" "vnet" {
+  name = "myTFVnet"
+  count = 4 /* inline */ # trailing
+}`,
+			Expected: `
+resource "azurerm_virtual_network" "vnet" {
+  count = 4 /* inline */ # trailing
+
+  name = "myTFVnet"
+}`,
+		},
+		{
+			Name: "keep comments above the first argument",
+			Content: `
+resource "azurerm_virtual_network" "vnet" {
+  # managed by the platform team
+
+  name = "myTFVnet"
+  count = 4
+}`,
+			Expected: `
+resource "azurerm_virtual_network" "vnet" {
+  # managed by the platform team
+
+  count = 4
+
+  name = "myTFVnet"
+}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rule := NewTerraformResourceDataArgLayoutRule()
+			runner := helper.TestRunner(t, map[string]string{"config.tf": tc.Content})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			helper.AssertChanges(t, map[string]string{"config.tf": tc.Expected}, runner.Changes())
+		})
+	}
+}
+
+func Test_TerraformResourceDataArgLayout_MetaArgConfig(t *testing.T) {
+	config := `
+rule "terraform_resource_data_arg_layout" {
+  enabled        = true
+  head_meta_args = { count = 1, for_each = 1, provider = 0 }
+  tail_meta_args = { depends_on = 0, lifecycle = 1 }
+}`
+	code := `
+resource "azurerm_virtual_network" "vnet" {
+  provider = azurerm.europe
+  count    = 4
+
+  name = "myTFVnet"
+
+  depends_on = [
+    azurerm_resource_group.example
+  ]
+  lifecycle {
+    create_before_destroy = true
+  }
+}`
+
+	rule := NewTerraformResourceDataArgLayoutRule()
+	runner := helper.TestRunner(t, map[string]string{"config.tf": code, ".tflint.hcl": config})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	AssertIssuesWithoutRange(t, helper.Issues{
+		{
+			Rule: NewTerraformResourceDataArgLayoutRule(),
+			Message: `Arguments are expected to be arranged in following Layout:
+resource "azurerm_virtual_network" "vnet" {
+  count    = 4
+  provider = azurerm.europe
+
+  name = "myTFVnet"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  depends_on = [
+    azurerm_resource_group.example
+  ]
+}`,
+		},
+	}, runner.Issues)
+}
+
+func Test_TerraformResourceDataArgLayout_InvalidMetaArgConfig(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config string
+	}{
+		{
+			Name: "unknown meta arg",
+			Config: `
+rule "terraform_resource_data_arg_layout" {
+  enabled        = true
+  head_meta_args = { count = 0, name = 1 }
+}`,
+		},
+		{
+			Name: "conflicting meta arg",
+			Config: `
+rule "terraform_resource_data_arg_layout" {
+  enabled        = true
+  head_meta_args = { count = 0, provider = 1 }
+  tail_meta_args = { provider = 0, depends_on = 1 }
+}`,
+		},
+		{
+			Name: "lifecycle as head meta arg",
+			Config: `
+rule "terraform_resource_data_arg_layout" {
+  enabled        = true
+  head_meta_args = { lifecycle = 0 }
+  tail_meta_args = { depends_on = 0 }
+}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rule := NewTerraformResourceDataArgLayoutRule()
+			runner := helper.TestRunner(t, map[string]string{"config.tf": `resource "null_resource" "this" {}`, ".tflint.hcl": tc.Config})
+			assert.Error(t, rule.Check(runner))
+		})
+	}
+}
+
+func Test_TerraformResourceDataArgLayout_ArgOrderProfile(t *testing.T) {
+	schemaFile := filepath.Join(t.TempDir(), "arg_order.yaml")
+	require.NoError(t, os.WriteFile(schemaFile, []byte(`
+resource:
+  azurerm_resource_group:
+    required:
+      - name
+    optional:
+      - location
+`), 0600))
+	code := `
+resource "azurerm_resource_group" "rg" {
+  tags     = {}
+  location = "eastus"
+  name     = "rg"
+}
+
+resource "azurerm_resource_group" "sorted" {
+  location = "eastus"
+  name     = "rg"
+  tags     = {}
+}
+
+resource "azurerm_kubernetes_cluster" "no_profile" {
+  name     = "aks"
+  location = "eastus"
+}`
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "bundled profile",
+			Config: `
+rule "terraform_resource_data_arg_layout" {
+  enabled           = true
+  arg_order_profile = "required_first"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule: NewTerraformResourceDataArgLayoutRule(),
+					Message: `Arguments are expected to be arranged in following Layout:
+resource "azurerm_resource_group" "rg" {
+  location = "eastus"
+  name     = "rg"
+  tags     = {}
+}`,
+				},
+			},
+		},
+		{
+			Name: "user supplied profile",
+			Config: fmt.Sprintf(`
+rule "terraform_resource_data_arg_layout" {
+  enabled               = true
+  arg_order_profile     = "required_first"
+  arg_order_schema_file = %q
+}`, schemaFile),
+			Expected: helper.Issues{
+				{
+					Rule: NewTerraformResourceDataArgLayoutRule(),
+					Message: `Arguments are expected to be arranged in following Layout:
+resource "azurerm_resource_group" "rg" {
+  name     = "rg"
+  location = "eastus"
+  tags     = {}
+}`,
+				},
+				{
+					Rule: NewTerraformResourceDataArgLayoutRule(),
+					Message: `Arguments are expected to be arranged in following Layout:
+resource "azurerm_resource_group" "sorted" {
+  name     = "rg"
+  location = "eastus"
+  tags     = {}
+}`,
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			rule := NewTerraformResourceDataArgLayoutRule()
+			runner := helper.TestRunner(t, map[string]string{"config.tf": code, ".tflint.hcl": tc.Config})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssuesWithoutRange(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = &TerraformStandardModuleStructureRule{}
+
+// TerraformStandardModuleStructureRule checks whether the blocks are declared in the expected files and the required files exist
+type TerraformStandardModuleStructureRule struct {
+	tflint.DefaultRule
+}
+
+type terraformStandardModuleStructureRuleConfig struct {
+	RequiredFiles []string            `hclext:"required_files,optional"`
+	BlockFiles    map[string][]string `hclext:"block_files,optional"`
+}
+
+// defaultRequiredFiles returns the files required by default, following the `file_names` plugin option
+func defaultRequiredFiles() []string {
+	return []string{fileName("main"), fileName("outputs"), fileName("variables")}
+}
+
+// defaultBlockFiles returns the files expected to declare each block type by default, following the `file_names` plugin option
+func defaultBlockFiles() map[string][]string {
+	return map[string][]string{
+		"locals":    {fileName("locals"), fileName("main")},
+		"output":    {fileName("outputs")},
+		"provider":  {fileName("providers")},
+		"terraform": {fileName("versions")},
+		"variable":  {fileName("variables")},
+	}
+}
+
+// NewTerraformStandardModuleStructureRule returns a new rule
+func NewTerraformStandardModuleStructureRule() *TerraformStandardModuleStructureRule {
+	return &TerraformStandardModuleStructureRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformStandardModuleStructureRule) Name() string {
+	return "terraform_standard_module_structure"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformStandardModuleStructureRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformStandardModuleStructureRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *TerraformStandardModuleStructureRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether the module follows the configured file layout
+func (r *TerraformStandardModuleStructureRule) Check(runner tflint.Runner) error {
+	config := terraformStandardModuleStructureRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	if config.RequiredFiles == nil {
+		config.RequiredFiles = defaultRequiredFiles()
+	}
+	if config.BlockFiles == nil {
+		config.BlockFiles = defaultBlockFiles()
+	}
+	err := r.checkRequiredFiles(runner, config.RequiredFiles)
+	walker := &Walker{
+		Blocks: []BlockVisitor{{
+			TopLevel: true,
+			Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+				return r.checkBlock(ctx.Runner, block, config.BlockFiles)
+			},
+		}},
+	}
+	if subErr := walker.Walk(runner); subErr != nil {
+		err = multierror.Append(err, subErr)
+	}
+	return err
+}
+
+func (r *TerraformStandardModuleStructureRule) checkRequiredFiles(runner tflint.Runner, requiredFiles []string) error {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	existingFiles := make(map[string]bool)
+	for filename := range files {
+		existingFiles[strings.TrimSuffix(filepath.Base(filename), ".json")] = true
+	}
+	location, err := r.moduleRange(runner)
+	if err != nil {
+		return err
+	}
+	for _, requiredFile := range requiredFiles {
+		if existingFiles[requiredFile] {
+			continue
+		}
+		if subErr := runner.EmitIssue(r, fmt.Sprintf("`%s` is required by the module structure but missing", requiredFile), location); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+// moduleRange returns the start of the first linted `.tf` file of the module to report the issues of the module as a whole,
+// so that they can be ignored by annotations like the other issues
+func (r *TerraformStandardModuleStructureRule) moduleRange(runner tflint.Runner) (hcl.Range, error) {
+	files, err := lintedFiles(runner)
+	if err != nil {
+		return hcl.Range{}, err
+	}
+	var filenames []string
+	for filename := range files {
+		if strings.HasSuffix(filename, ".tf") {
+			filenames = append(filenames, filename)
+		}
+	}
+	if len(filenames) == 0 {
+		return hcl.Range{}, nil
+	}
+	sort.Strings(filenames)
+	return hcl.Range{Filename: filenames[0], Start: hcl.InitialPos, End: hcl.InitialPos}, nil
+}
+
+func (r *TerraformStandardModuleStructureRule) checkBlock(runner tflint.Runner, block *hclsyntax.Block, blockFiles map[string][]string) error {
+	allowedFiles, declared := blockFiles[block.Type]
+	if !declared || slices.Contains(allowedFiles, filepath.Base(block.Range().Filename)) {
+		return nil
+	}
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("`%s` block is expected to be declared in %s", block.Type, r.quotedFiles(allowedFiles)),
+		block.DefRange(),
+	)
+}
+
+func (r *TerraformStandardModuleStructureRule) quotedFiles(filenames []string) string {
+	var quoted []string
+	for _, filename := range filenames {
+		quoted = append(quoted, fmt.Sprintf("`%s`", filename))
+	}
+	return strings.Join(quoted, " or ")
+}
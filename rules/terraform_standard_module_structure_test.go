@@ -0,0 +1,191 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformStandardModuleStructureRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. standard structure",
+			Files: map[string]string{
+				"main.tf": `
+locals {
+  name = "example"
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = local.name
+  location = var.location
+}`,
+				"variables.tf": `
+variable "location" {
+  type = string
+}`,
+				"outputs.tf": `
+output "id" {
+  value = azurerm_resource_group.rg.id
+}`,
+				"versions.tf": `
+terraform {
+  required_version = ">= 1.3"
+}`,
+				"providers.tf": `
+provider "azurerm" {
+  features {}
+}`,
+				"main_override.tf": `
+variable "location" {
+  default = "eastus"
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. blocks in wrong files and missing files",
+			Files: map[string]string{
+				"main.tf": `
+terraform {
+  required_version = ">= 1.3"
+}
+
+variable "location" {
+  type = string
+}
+
+output "id" {
+  value = azurerm_resource_group.rg.id
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "example"
+  location = var.location
+}`,
+				"misc.tf": `
+provider "azurerm" {
+  features {}
+}
+
+locals {
+  name = "example"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformStandardModuleStructureRule(),
+					Message: "`outputs.tf` is required by the module structure but missing",
+				},
+				{
+					Rule:    NewTerraformStandardModuleStructureRule(),
+					Message: "`variables.tf` is required by the module structure but missing",
+				},
+				{
+					Rule:    NewTerraformStandardModuleStructureRule(),
+					Message: "`terraform` block is expected to be declared in `versions.tf`",
+				},
+				{
+					Rule:    NewTerraformStandardModuleStructureRule(),
+					Message: "`variable` block is expected to be declared in `variables.tf`",
+				},
+				{
+					Rule:    NewTerraformStandardModuleStructureRule(),
+					Message: "`output` block is expected to be declared in `outputs.tf`",
+				},
+				{
+					Rule:    NewTerraformStandardModuleStructureRule(),
+					Message: "`provider` block is expected to be declared in `providers.tf`",
+				},
+				{
+					Rule:    NewTerraformStandardModuleStructureRule(),
+					Message: "`locals` block is expected to be declared in `locals.tf` or `main.tf`",
+				},
+			},
+		},
+		{
+			Name: "3. custom structure",
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "terraform_standard_module_structure" {
+  enabled        = true
+  required_files = ["main.tf", "terraform.tf"]
+  block_files = {
+    terraform = ["terraform.tf"]
+    provider  = ["terraform.tf"]
+    resource  = ["main.tf"]
+  }
+}`,
+				"main.tf": `
+variable "location" {
+  type = string
+}
+
+provider "azurerm" {
+  features {}
+}`,
+				"network.tf": `
+resource "azurerm_virtual_network" "vnet" {
+  name = "example"
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformStandardModuleStructureRule(),
+					Message: "`terraform.tf` is required by the module structure but missing",
+				},
+				{
+					Rule:    NewTerraformStandardModuleStructureRule(),
+					Message: "`provider` block is expected to be declared in `terraform.tf`",
+				},
+				{
+					Rule:    NewTerraformStandardModuleStructureRule(),
+					Message: "`resource` block is expected to be declared in `main.tf`",
+				},
+			},
+		},
+	}
+	rule := NewTerraformStandardModuleStructureRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformStandardModuleStructureRule_MissingFileRange(t *testing.T) {
+	rule := NewTerraformStandardModuleStructureRule()
+	runner := helper.TestRunner(t, map[string]string{
+		".tflint.hcl": `
+rule "terraform_standard_module_structure" {
+  enabled        = true
+  required_files = ["main.tf", "variables.tf", "outputs.tf"]
+}`,
+		"outputs.tf": "",
+		"main.tf":    "",
+	})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformStandardModuleStructureRule(),
+			Message: "`variables.tf` is required by the module structure but missing",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.InitialPos,
+				End:      hcl.InitialPos,
+			},
+		},
+	}, runner.Issues)
+}
@@ -0,0 +1,192 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = &TerraformUnusedDeclarationsRule{}
+
+// TerraformUnusedDeclarationsRule checks whether there are variables, locals or data sources declared but never referenced
+type TerraformUnusedDeclarationsRule struct {
+	tflint.DefaultRule
+}
+
+type declaration struct {
+	kind       string
+	name       string
+	key        string
+	scope      hcl.Range
+	issueRange hcl.Range
+}
+
+// NewTerraformUnusedDeclarationsRule returns a new rule
+func NewTerraformUnusedDeclarationsRule() *TerraformUnusedDeclarationsRule {
+	return &TerraformUnusedDeclarationsRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformUnusedDeclarationsRule) Name() string {
+	return "terraform_unused_declarations"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformUnusedDeclarationsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformUnusedDeclarationsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformUnusedDeclarationsRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether every variable, local value and data source is referenced in the module
+func (r *TerraformUnusedDeclarationsRule) Check(runner tflint.Runner) error {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	// references count in every file of the module, including the override files and the files which aren't linted
+	references := make(map[string][]hcl.Range)
+	for _, file := range files {
+		r.collectReferences(file, references)
+	}
+	var declarations []declaration
+	walker := &Walker{
+		Blocks: []BlockVisitor{{
+			TopLevel: true,
+			Visit: func(_ *WalkContext, block *hclsyntax.Block) error {
+				declarations = append(declarations, r.declarations(block)...)
+				return nil
+			},
+		}},
+	}
+	if err := walker.Walk(runner); err != nil {
+		return err
+	}
+
+	for _, d := range declarations {
+		if r.referenced(d, references[d.key]) {
+			continue
+		}
+		if subErr := runner.EmitIssue(r, fmt.Sprintf("%s `%s` is declared but not used", d.kind, d.name), d.issueRange); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+func (r *TerraformUnusedDeclarationsRule) declarations(block *hclsyntax.Block) []declaration {
+	switch block.Type {
+	case "variable":
+		name := block.Labels[0]
+		return []declaration{{
+			kind:       "variable",
+			name:       name,
+			key:        fmt.Sprintf("var.%s", name),
+			scope:      block.Range(),
+			issueRange: block.DefRange(),
+		}}
+	case "data":
+		name := fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])
+		return []declaration{{
+			kind:       "data",
+			name:       name,
+			key:        fmt.Sprintf("data.%s", name),
+			scope:      block.Range(),
+			issueRange: block.DefRange(),
+		}}
+	case "locals":
+		var declarations []declaration
+		for _, attr := range attributesByLines(block.Body.Attributes) {
+			declarations = append(declarations, declaration{
+				kind:       "local value",
+				name:       attr.Name,
+				key:        fmt.Sprintf("local.%s", attr.Name),
+				scope:      attr.SrcRange,
+				issueRange: attr.NameRange,
+			})
+		}
+		return declarations
+	}
+	return nil
+}
+
+// collectReferences records every `var.x`, `local.x` and `data.x.y` traversal in the file,
+// including the ones in dynamic blocks, for expressions, templates and heredocs
+func (r *TerraformUnusedDeclarationsRule) collectReferences(file *hcl.File, references map[string][]hcl.Range) {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		r.collectJSONReferences(file, references)
+		return
+	}
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok {
+			return nil
+		}
+		if key := referenceKey(expr.Traversal); key != "" {
+			references[key] = append(references[key], expr.SrcRange)
+		}
+		return nil
+	})
+}
+
+// collectJSONReferences records the traversals in the string templates of a JSON file, e.g. `"${var.x}"`,
+// every property is read as an attribute since a JSON file doesn't tell blocks from attributes without schema
+func (r *TerraformUnusedDeclarationsRule) collectJSONReferences(file *hcl.File, references map[string][]hcl.Range) {
+	attributes, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		logger.Debug(fmt.Sprintf("skip collecting references in %s: %s", file.Body.MissingItemRange().Filename, diags))
+	}
+	for _, attr := range attributes {
+		for _, traversal := range attr.Expr.Variables() {
+			if key := referenceKey(traversal); key != "" {
+				references[key] = append(references[key], traversal.SourceRange())
+			}
+		}
+	}
+}
+
+// referenced checks whether the declaration is referenced outside of itself, e.g. `var.x` in the validation of variable `x` doesn't count
+func (r *TerraformUnusedDeclarationsRule) referenced(d declaration, references []hcl.Range) bool {
+	for _, ref := range references {
+		if ref.Filename != d.scope.Filename || !d.scope.ContainsOffset(ref.Start.Byte) {
+			return true
+		}
+	}
+	return false
+}
+
+func referenceKey(traversal hcl.Traversal) string {
+	var names []string
+	for _, step := range traversal {
+		switch s := step.(type) {
+		case hcl.TraverseRoot:
+			names = append(names, s.Name)
+			continue
+		case hcl.TraverseAttr:
+			names = append(names, s.Name)
+			continue
+		}
+		break
+	}
+	switch {
+	case len(names) >= 2 && (names[0] == "var" || names[0] == "local"):
+		return fmt.Sprintf("%s.%s", names[0], names[1])
+	case len(names) >= 3 && names[0] == "data":
+		return fmt.Sprintf("data.%s.%s", names[1], names[2])
+	}
+	return ""
+}
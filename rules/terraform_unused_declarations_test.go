@@ -0,0 +1,161 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformUnusedDeclarationsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. all declarations referenced across files",
+			Files: map[string]string{
+				"variables.tf": `
+variable "rules" {
+  type = list(string)
+}
+
+variable "prefix" {
+  type = string
+}
+
+variable "tags" {
+  type = map(string)
+}
+
+variable "policy_name" {
+  type = string
+}`,
+				"locals.tf": `
+locals {
+  name = "${var.prefix}-nsg"
+  policy = <<EOT
+{
+  "name": "${var.policy_name}"
+}
+EOT
+}`,
+				"main.tf": `
+data "azurerm_resource_group" "this" {
+  name = local.name
+}
+
+resource "azurerm_network_security_group" "this" {
+  name                = local.name
+  location            = data.azurerm_resource_group.this.location
+  resource_group_name = data.azurerm_resource_group.this.name
+  tags                = { for k, v in var.tags : k => v }
+
+  dynamic "security_rule" {
+    for_each = var.rules
+
+    content {
+      name = security_rule.value
+    }
+  }
+}
+
+output "policy" {
+  value = local.policy
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. unused declarations",
+			Files: map[string]string{
+				"main.tf": `
+variable "used" {
+  type = string
+}
+
+variable "unused" {
+  type = string
+
+  validation {
+    condition     = length(var.unused) > 0
+    error_message = "must not be empty"
+  }
+}
+
+locals {
+  used_local   = var.used
+  unused_local = "unused"
+}
+
+data "azurerm_client_config" "current" {}
+
+output "used" {
+  value = local.used_local
+}`,
+				"main_override.tf": `
+variable "only_in_override" {
+  type = string
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformUnusedDeclarationsRule(),
+					Message: "variable `unused` is declared but not used",
+				},
+				{
+					Rule:    NewTerraformUnusedDeclarationsRule(),
+					Message: "local value `unused_local` is declared but not used",
+				},
+				{
+					Rule:    NewTerraformUnusedDeclarationsRule(),
+					Message: "data `azurerm_client_config.current` is declared but not used",
+				},
+			},
+		},
+		{
+			Name: "3. declarations referenced in JSON files",
+			Files: map[string]string{
+				"main.tf": `
+variable "a" {
+  type = string
+}
+
+variable "unused" {
+  type = string
+}
+
+locals {
+  b = "b"
+}`,
+				"main.tf.json": `{
+  "resource": {
+    "azurerm_resource_group": {
+      "example": {
+        "name": "${var.a}",
+        "tags": {"b": "${local.b}"}
+      }
+    }
+  }
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformUnusedDeclarationsRule(),
+					Message: "variable `unused` is declared but not used",
+				},
+			},
+		},
+	}
+	rule := NewTerraformUnusedDeclarationsRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.Files)
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = &TerraformUnusedSuppressionsRule{}
+
+// TerraformUnusedSuppressionsRule checks whether the `basic-ext:ignore` and `basic-ext:ignore-file` comments suppress any issue
+type TerraformUnusedSuppressionsRule struct {
+	tflint.DefaultRule
+}
+
+// NewTerraformUnusedSuppressionsRule returns a new rule
+func NewTerraformUnusedSuppressionsRule() *TerraformUnusedSuppressionsRule {
+	return &TerraformUnusedSuppressionsRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformUnusedSuppressionsRule) Name() string {
+	return "terraform_unused_suppressions"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformUnusedSuppressionsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformUnusedSuppressionsRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *TerraformUnusedSuppressionsRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check runs the suppressed rules without the suppressions and reports the suppressions matching none of their issues
+func (r *TerraformUnusedSuppressionsRule) Check(runner tflint.Runner) error {
+	files, err := lintedFiles(runner)
+	if err != nil {
+		return err
+	}
+	var filenames []string
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	var suppressions []suppression
+	for _, filename := range filenames {
+		suppressions = append(suppressions, collectSuppressions(files[filename])...)
+	}
+
+	issues := make(map[string][]hcl.Range)
+	// failed are the rules which can't be evaluated, e.g. a disabled rule missing its required config
+	failed := make(map[string]bool)
+	for _, s := range suppressions {
+		if _, checked := issues[s.Rule]; checked || failed[s.Rule] || s.Rule == r.Name() {
+			continue
+		}
+		rule := ruleByName(s.Rule)
+		if rule == nil {
+			continue
+		}
+		recorder := newIssueRecorder(runner)
+		if subErr := rule.Check(recorder); subErr != nil {
+			logger.Debug(fmt.Sprintf("skip the suppressions of %s since the rule can't be evaluated: %s", s.Rule, subErr))
+			failed[s.Rule] = true
+			continue
+		}
+		issues[s.Rule] = recorder.locations
+	}
+
+	for _, s := range suppressions {
+		var msg string
+		switch {
+		case s.Rule == r.Name() || failed[s.Rule]:
+			continue
+		case ruleByName(s.Rule) == nil:
+			msg = fmt.Sprintf("suppression of unknown rule `%s`", s.Rule)
+		case !r.used(s, issues[s.Rule]):
+			msg = fmt.Sprintf("suppression of `%s` is unused", s.Rule)
+		default:
+			continue
+		}
+		if subErr := runner.EmitIssue(r, msg, s.Comment); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+func (r *TerraformUnusedSuppressionsRule) used(s suppression, locations []hcl.Range) bool {
+	for _, location := range locations {
+		if s.covers(location) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleByName returns the rule of this ruleset with the name, or nil if there isn't
+func ruleByName(name string) tflint.Rule {
+	for _, rule := range Rules {
+		if rule.Name() == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// issueRecorder records the locations of the issues of a rule instead of emitting them, fixes are not applied
+type issueRecorder struct {
+	tflint.Runner
+	locations []hcl.Range
+}
+
+// newIssueRecorder returns a recorder on top of the runner given by the host, so that the config of the recorded rule
+// is neither decoded along with the shared options twice nor stored as the options of the calling rule
+func newIssueRecorder(runner tflint.Runner) *issueRecorder {
+	if optionsRunner, ok := runner.(*ruleOptionsRunner); ok {
+		runner = optionsRunner.Runner
+	}
+	return &issueRecorder{Runner: runner}
+}
+
+// DecodeRuleConfig decodes the config of the rule, ignoring the options shared by all rules
+func (r *issueRecorder) DecodeRuleConfig(name string, ret interface{}) error {
+	_, err := decodeRuleConfigWithOptions(r.Runner, name, ret)
+	return err
+}
+
+// EmitIssue records the location of the issue
+func (r *issueRecorder) EmitIssue(_ tflint.Rule, _ string, location hcl.Range) error {
+	r.locations = append(r.locations, location)
+	return nil
+}
+
+// EmitIssueWithFix records the location of the issue without running the fix
+func (r *issueRecorder) EmitIssueWithFix(_ tflint.Rule, _ string, location hcl.Range, _ func(f tflint.Fixer) error) error {
+	r.locations = append(r.locations, location)
+	return nil
+}
@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_TerraformUnusedSuppressionsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. used suppressions",
+			Content: `
+# basic-ext:ignore-file terraform_variable_order
+variable "b" {}
+
+# basic-ext:ignore terraform_variable_type_required
+variable "a" {
+  description = "a"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. unused and unknown suppressions",
+			Content: `
+# basic-ext:ignore-file terraform_variable_order terraform_unknown
+variable "a" {}
+
+# basic-ext:ignore terraform_variable_type_required
+variable "b" {
+  type = string
+}
+
+# basic-ext:ignore terraform_variable_type_required
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformUnusedSuppressionsRule(),
+					Message: "suppression of `terraform_variable_order` is unused",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+						End:      hcl.Pos{Line: 3, Column: 1, Byte: 66},
+					},
+				},
+				{
+					Rule:    NewTerraformUnusedSuppressionsRule(),
+					Message: "suppression of unknown rule `terraform_unknown`",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+						End:      hcl.Pos{Line: 3, Column: 1, Byte: 66},
+					},
+				},
+				{
+					Rule:    NewTerraformUnusedSuppressionsRule(),
+					Message: "suppression of `terraform_variable_type_required` is unused",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 5, Column: 1, Byte: 84},
+						End:      hcl.Pos{Line: 6, Column: 1, Byte: 136},
+					},
+				},
+				{
+					Rule:    NewTerraformUnusedSuppressionsRule(),
+					Message: "suppression of `terraform_variable_type_required` is unused",
+					Range: hcl.Range{
+						Filename: "variables.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1, Byte: 172},
+						End:      hcl.Pos{Line: 11, Column: 1, Byte: 224},
+					},
+				},
+			},
+		},
+		{
+			Name: "3. suppressions of rules which can't be evaluated",
+			Content: `
+# basic-ext:ignore terraform_deprecated_argument_usage
+variable "a" {
+  type = string
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+	rule := NewTerraformUnusedSuppressionsRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"variables.tf": tc.Content})
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformUnusedSuppressionsRule_RuleSet(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		".tflint.hcl": `
+rule "terraform_resource_data_arg_layout" {
+  enabled        = true
+  severity       = "error"
+  tail_meta_args = { depends_on = 0 }
+}
+
+rule "terraform_unused_suppressions" {
+  enabled        = true
+  message_prefix = "[suppressions]"
+}`,
+		"main.tf": `
+# basic-ext:ignore terraform_resource_data_arg_layout
+resource "azurerm_resource_group" "example" {
+  name     = "example"
+  location = "westus"
+}`,
+	})
+	ruleSet := NewRuleSet("basic-ext", "0.0.1", []tflint.Rule{NewTerraformUnusedSuppressionsRule()})
+	if err := ruleSet.Rules[0].Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    ruleSet.Rules[0],
+			Message: "[suppressions] suppression of `terraform_resource_data_arg_layout` is unused",
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+				End:      hcl.Pos{Line: 3, Column: 1, Byte: 54},
+			},
+		},
+	}, runner.Issues)
+}
@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var _ tflint.Rule = &TerraformVariableDescriptionRequiredRule{}
+
+// TerraformVariableDescriptionRequiredRule checks whether variables and outputs have a proper description
+type TerraformVariableDescriptionRequiredRule struct {
+	tflint.DefaultRule
+}
+
+type terraformVariableDescriptionRequiredRuleConfig struct {
+	MinLength      int  `hclext:"min_length,optional"`
+	SentenceCase   bool `hclext:"sentence_case,optional"`
+	TrailingPeriod bool `hclext:"trailing_period,optional"`
+}
+
+// NewTerraformVariableDescriptionRequiredRule returns a new rule
+func NewTerraformVariableDescriptionRequiredRule() *TerraformVariableDescriptionRequiredRule {
+	return &TerraformVariableDescriptionRequiredRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformVariableDescriptionRequiredRule) Name() string {
+	return "terraform_variable_description_required"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformVariableDescriptionRequiredRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformVariableDescriptionRequiredRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *TerraformVariableDescriptionRequiredRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether variables and outputs have a proper description
+func (r *TerraformVariableDescriptionRequiredRule) Check(runner tflint.Runner) error {
+	config := terraformVariableDescriptionRequiredRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	visit := func(ctx *WalkContext, block *hclsyntax.Block) error {
+		return r.checkBlock(ctx.Runner, block, config)
+	}
+	walker := &Walker{
+		Blocks: []BlockVisitor{
+			{Type: "variable", TopLevel: true, Visit: visit},
+			{Type: "output", TopLevel: true, Visit: visit},
+		},
+	}
+	return walker.Walk(runner)
+}
+
+func (r *TerraformVariableDescriptionRequiredRule) checkBlock(runner tflint.Runner, block *hclsyntax.Block, config terraformVariableDescriptionRequiredRuleConfig) error {
+	var err error
+	for _, msg := range r.descriptionProblems(block, config) {
+		if subErr := runner.EmitIssue(r, msg, block.DefRange()); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+func (r *TerraformVariableDescriptionRequiredRule) descriptionProblems(block *hclsyntax.Block, config terraformVariableDescriptionRequiredRuleConfig) []string {
+	name := fmt.Sprintf("%s `%s`", block.Type, block.Labels[0])
+	attr, defined := block.Body.Attributes["description"]
+	if !defined {
+		return []string{fmt.Sprintf("`description` is required for %s", name)}
+	}
+	v, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !v.IsKnown() || v.IsNull() || v.Type() != cty.String {
+		logger.Debug(fmt.Sprintf("skip checking description of %s since it's not a literal string", name))
+		return nil
+	}
+	description := strings.TrimSpace(v.AsString())
+	if description == "" {
+		return []string{fmt.Sprintf("`description` of %s should not be empty", name)}
+	}
+	var problems []string
+	if config.MinLength > 0 && utf8.RuneCountInString(description) < config.MinLength {
+		problems = append(problems, fmt.Sprintf("`description` of %s should have at least %d characters", name, config.MinLength))
+	}
+	if first, _ := utf8.DecodeRuneInString(description); config.SentenceCase && unicode.IsLetter(first) && !unicode.IsUpper(first) {
+		problems = append(problems, fmt.Sprintf("`description` of %s should start with an uppercase letter", name))
+	}
+	if config.TrailingPeriod && !strings.HasSuffix(description, ".") {
+		problems = append(problems, fmt.Sprintf("`description` of %s should end with a period", name))
+	}
+	return problems
+}
@@ -0,0 +1,140 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformVariableDescriptionRequiredRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. variables and outputs with description",
+			Content: `
+variable "image_id" {
+  type        = string
+  description = "The id of the machine image (AMI) to use for the server."
+}
+
+output "instance_ip_addr" {
+  value       = aws_instance.server.private_ip
+  description = <<-EOT
+  The private IP address of the main server instance.
+  EOT
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. missing and empty description",
+			Content: `
+variable "image_id" {
+  type = string
+}
+
+output "instance_ip_addr" {
+  value       = aws_instance.server.private_ip
+  description = "  "
+}
+
+locals {
+  description = ""
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformVariableDescriptionRequiredRule(),
+					Message: "`description` is required for variable `image_id`",
+				},
+				{
+					Rule:    NewTerraformVariableDescriptionRequiredRule(),
+					Message: "`description` of output `instance_ip_addr` should not be empty",
+				},
+			},
+		},
+		{
+			Name: "3. quality checks",
+			Config: `
+rule "terraform_variable_description_required" {
+  enabled         = true
+  min_length      = 10
+  sentence_case   = true
+  trailing_period = true
+}`,
+			Content: `
+variable "image_id" {
+  type        = string
+  description = "image id"
+}
+
+variable "zone" {
+  type        = string
+  description = "The availability zone of the instance."
+}
+
+output "instance_ip_addr" {
+  value       = aws_instance.server.private_ip
+  description = "` + "`private_ip`" + ` of the main server instance"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformVariableDescriptionRequiredRule(),
+					Message: "`description` of variable `image_id` should have at least 10 characters",
+				},
+				{
+					Rule:    NewTerraformVariableDescriptionRequiredRule(),
+					Message: "`description` of variable `image_id` should start with an uppercase letter",
+				},
+				{
+					Rule:    NewTerraformVariableDescriptionRequiredRule(),
+					Message: "`description` of variable `image_id` should end with a period",
+				},
+				{
+					Rule:    NewTerraformVariableDescriptionRequiredRule(),
+					Message: "`description` of output `instance_ip_addr` should end with a period",
+				},
+			},
+		},
+	}
+	rule := NewTerraformVariableDescriptionRequiredRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"config.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformVariableDescriptionRequiredRule_SkipOverrideFile(t *testing.T) {
+	rule := NewTerraformVariableDescriptionRequiredRule()
+	runner := helper.TestRunner(t, map[string]string{
+		"variables.tf": `
+variable "location" {
+  type        = string
+  description = "The location of the resources."
+}`,
+		"override.tf": `
+variable "location" {
+  default = "westus"
+}
+
+output "location" {
+  value = var.location
+}`,
+	})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	AssertIssues(t, helper.Issues{}, runner.Issues)
+}
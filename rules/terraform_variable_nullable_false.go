@@ -54,6 +54,9 @@ func (r *TerraformVariableNullableFalseRule) Check(runner tflint.Runner) error {
 		return err
 	}
 	for _, b := range content.Blocks {
+		if !isLintedFile(b.DefRange.Filename) {
+			continue
+		}
 		attribute, ok := b.Body.Attributes["nullable"]
 		if !ok {
 			continue
@@ -2,13 +2,11 @@ package rules
 
 import (
 	"fmt"
-	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"reflect"
 	"sort"
 	"strings"
 
 	"github.com/Azure/tflint-ruleset-basic-ext/project"
-	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
@@ -47,48 +45,71 @@ func (r *TerraformVariableOrderRule) Link() string {
 
 // Check checks whether the variables are sorted in expected order
 func (r *TerraformVariableOrderRule) Check(runner tflint.Runner) error {
-	files, err := runner.GetFiles()
-	if err != nil {
-		return err
+	var variables hclsyntax.Blocks
+	walker := &Walker{
+		IncludeOverrideFiles: true,
+		File: func(ctx *WalkContext) error {
+			variables = nil
+			return nil
+		},
+		Blocks: []BlockVisitor{{
+			Type:     "variable",
+			TopLevel: true,
+			Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+				variables = append(variables, block)
+				return nil
+			},
+		}},
+		AfterFile: func(ctx *WalkContext) error {
+			return r.checkVariableOrder(ctx.Runner, ctx.File, variables)
+		},
 	}
-	for _, file := range files {
-		if subErr := r.checkVariableOrder(runner, file); subErr != nil {
-			err = multierror.Append(err, subErr)
-		}
-	}
-	return err
+	return walker.Walk(runner)
 }
 
-func (r *TerraformVariableOrderRule) checkVariableOrder(runner tflint.Runner, file *hcl.File) error {
-	body, ok := file.Body.(*hclsyntax.Body)
-	if !ok {
-		logger.Debug("skip terraform_variable_order check since it's not hcl file")
+func (r *TerraformVariableOrderRule) checkVariableOrder(runner tflint.Runner, file *hcl.File, variables hclsyntax.Blocks) error {
+	if len(variables) == 0 {
 		return nil
 	}
-	blocks := body.Blocks
-
-	requiredVars := r.getSortedVariableNames(blocks, false)
-	optionalVars := r.getSortedVariableNames(blocks, true)
+	requiredVars := r.getSortedVariableNames(variables, false)
+	optionalVars := r.getSortedVariableNames(variables, true)
 	sortedVariableNames := append(requiredVars, optionalVars...)
 
-	variableNames := r.getVariableNames(blocks)
+	variableNames := r.getVariableNames(variables)
 	if reflect.DeepEqual(variableNames, sortedVariableNames) {
 		return nil
 	}
 
-	firstRange := r.firstVariableRange(blocks)
-	sortedVariableHclTxts := r.sortedVariableCodeTxts(blocks, file, sortedVariableNames)
+	sortedVariableHclTxts := r.sortedVariableCodeTxts(variables, file, sortedVariableNames)
 	sortedVariableHclBytes := hclwrite.Format([]byte(strings.Join(sortedVariableHclTxts, "\n\n")))
 
-	return runner.EmitIssue(
+	return runner.EmitIssueWithFix(
 		r,
 		fmt.Sprintf("Recommended variable order:\n%s", sortedVariableHclBytes),
-		*firstRange,
+		variables[0].DefRange(),
+		func(f tflint.Fixer) error {
+			return ReorderWithComments(f, file, r.variableRanges(variables, variableNames), r.variableRanges(variables, sortedVariableNames))
+		},
 	)
 }
 
-func (r *TerraformVariableOrderRule) sortedVariableCodeTxts(blocks hclsyntax.Blocks, file *hcl.File, sortedVariableNames []string) []string {
-	variableHclTxts := r.variableCodeTxts(blocks, file)
+func (r *TerraformVariableOrderRule) variableRanges(variables hclsyntax.Blocks, variableNames []string) []hcl.Range {
+	variableRanges := make(map[string]hcl.Range)
+	for _, v := range variables {
+		variableRanges[v.Labels[0]] = v.Range()
+	}
+	var ranges []hcl.Range
+	for _, name := range variableNames {
+		ranges = append(ranges, variableRanges[name])
+	}
+	return ranges
+}
+
+func (r *TerraformVariableOrderRule) sortedVariableCodeTxts(variables hclsyntax.Blocks, file *hcl.File, sortedVariableNames []string) []string {
+	variableHclTxts := make(map[string]string)
+	for _, v := range variables {
+		variableHclTxts[v.Labels[0]] = string(v.Range().SliceBytes(file.Bytes))
+	}
 	var sortedVariableHclTxts []string
 	for _, name := range sortedVariableNames {
 		sortedVariableHclTxts = append(sortedVariableHclTxts, variableHclTxts[name])
@@ -96,48 +117,21 @@ func (r *TerraformVariableOrderRule) sortedVariableCodeTxts(blocks hclsyntax.Blo
 	return sortedVariableHclTxts
 }
 
-func (r *TerraformVariableOrderRule) variableCodeTxts(blocks hclsyntax.Blocks, file *hcl.File) map[string]string {
-	variableHclTxts := make(map[string]string)
-	r.forVariables(blocks, func(v *hclsyntax.Block) {
-		name := v.Labels[0]
-		variableHclTxts[name] = string(v.Range().SliceBytes(file.Bytes))
-	})
-	return variableHclTxts
-}
-
-func (r *TerraformVariableOrderRule) firstVariableRange(blocks hclsyntax.Blocks) *hcl.Range {
-	var firstRange *hcl.Range
-	r.forVariables(blocks, func(v *hclsyntax.Block) {
-		if firstRange == nil {
-			firstRange = ref(v.DefRange())
-		}
-	})
-	return firstRange
-}
-
-func (r *TerraformVariableOrderRule) getVariableNames(blocks hclsyntax.Blocks) []string {
+func (r *TerraformVariableOrderRule) getVariableNames(variables hclsyntax.Blocks) []string {
 	var variableNames []string
-	r.forVariables(blocks, func(v *hclsyntax.Block) {
+	for _, v := range variables {
 		variableNames = append(variableNames, v.Labels[0])
-	})
+	}
 	return variableNames
 }
 
-func (r *TerraformVariableOrderRule) getSortedVariableNames(blocks hclsyntax.Blocks, defaultWanted bool) []string {
+func (r *TerraformVariableOrderRule) getSortedVariableNames(variables hclsyntax.Blocks, defaultWanted bool) []string {
 	var sortedVariableNames []string
-	r.forVariables(blocks, func(v *hclsyntax.Block) {
+	for _, v := range variables {
 		if _, hasDefault := v.Body.Attributes["default"]; hasDefault == defaultWanted {
 			sortedVariableNames = append(sortedVariableNames, v.Labels[0])
 		}
-	})
+	}
 	sort.Strings(sortedVariableNames)
 	return sortedVariableNames
 }
-
-func (r *TerraformVariableOrderRule) forVariables(blocks hclsyntax.Blocks, action func(v *hclsyntax.Block)) {
-	for _, block := range blocks {
-		if block.Type == "variable" {
-			action(block)
-		}
-	}
-}
@@ -196,3 +196,45 @@ variable "docker_ports" {
 		})
 	}
 }
+
+func Test_TerraformVariableOrderRule_Fix(t *testing.T) {
+	content := `
+# the zones
+variable "availability_zone_names" {
+  type    = list(string)
+  default = ["us-west-1a"]
+}
+
+locals {
+  name = "example"
+}
+
+// the image
+// must exist in the region
+variable "image_id" {
+  type = string
+}`
+	expected := `
+// the image
+// must exist in the region
+variable "image_id" {
+  type = string
+}
+
+locals {
+  name = "example"
+}
+
+# the zones
+variable "availability_zone_names" {
+  type    = list(string)
+  default = ["us-west-1a"]
+}`
+
+	rule := NewTerraformVariableOrderRule()
+	runner := helper.TestRunner(t, map[string]string{"config.tf": content})
+	if err := rule.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	helper.AssertChanges(t, map[string]string{"config.tf": expected}, runner.Changes())
+}
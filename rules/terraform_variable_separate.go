@@ -41,7 +41,7 @@ func (r *TerraformVariableSeparateRule) Link() string {
 
 // Check checks whether the variables are separated from other types of blocks
 func (r *TerraformVariableSeparateRule) Check(runner tflint.Runner) error {
-	files, err := runner.GetFiles()
+	files, err := lintedFiles(runner)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,111 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Azure/tflint-ruleset-basic-ext/project"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+var _ tflint.Rule = &TerraformVariableTypeRequiredRule{}
+
+// TerraformVariableTypeRequiredRule checks whether every variable declares an exact type
+type TerraformVariableTypeRequiredRule struct {
+	tflint.DefaultRule
+}
+
+type terraformVariableTypeRequiredRuleConfig struct {
+	AllowAny          bool     `hclext:"allow_any,optional"`
+	AllowAnyVariables []string `hclext:"allow_any_variables,optional"`
+}
+
+// NewTerraformVariableTypeRequiredRule returns a new rule
+func NewTerraformVariableTypeRequiredRule() *TerraformVariableTypeRequiredRule {
+	return &TerraformVariableTypeRequiredRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformVariableTypeRequiredRule) Name() string {
+	return "terraform_variable_type_required"
+}
+
+// Link returns the rule reference link
+func (r *TerraformVariableTypeRequiredRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformVariableTypeRequiredRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformVariableTypeRequiredRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Check checks whether every variable declares `type` without `any`
+func (r *TerraformVariableTypeRequiredRule) Check(runner tflint.Runner) error {
+	config := terraformVariableTypeRequiredRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{
+						{
+							Name:     "type",
+							Required: false,
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	for _, b := range content.Blocks {
+		if !isLintedFile(b.DefRange.Filename) {
+			continue
+		}
+		name := b.Labels[0]
+		attribute, ok := b.Body.Attributes["type"]
+		if !ok {
+			if subErr := runner.EmitIssue(r, fmt.Sprintf("`type` is required for variable `%s`", name), b.DefRange); subErr != nil {
+				err = multierror.Append(err, subErr)
+			}
+			continue
+		}
+		if config.AllowAny || slices.Contains(config.AllowAnyVariables, name) || !r.containsAny(attribute.Expr) {
+			continue
+		}
+		subErr := runner.EmitIssue(
+			r,
+			fmt.Sprintf("`any` is used in the type of variable `%s`, declare an exact type instead", name),
+			attribute.Range,
+		)
+		if subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+// containsAny checks whether `any` is used in the type constraint, e.g. `any`, `map(any)` or `object({ a = list(any) })`
+func (r *TerraformVariableTypeRequiredRule) containsAny(expr hcl.Expression) bool {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() == "any" {
+			return true
+		}
+	}
+	return false
+}
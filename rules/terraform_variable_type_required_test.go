@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformVariableTypeRequiredRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "1. exact types",
+			Content: `
+variable "image_id" {
+  type = string
+}
+
+variable "settings" {
+  type = object({
+    any  = string
+    tags = map(string)
+  })
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "2. missing type and any",
+			Content: `
+variable "image_id" {}
+
+variable "anything" {
+  type = any
+}
+
+variable "tags" {
+  type = map(any)
+}
+
+variable "settings" {
+  type = object({
+    zones = list(any)
+  })
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformVariableTypeRequiredRule(),
+					Message: "`type` is required for variable `image_id`",
+				},
+				{
+					Rule:    NewTerraformVariableTypeRequiredRule(),
+					Message: "`any` is used in the type of variable `anything`, declare an exact type instead",
+				},
+				{
+					Rule:    NewTerraformVariableTypeRequiredRule(),
+					Message: "`any` is used in the type of variable `tags`, declare an exact type instead",
+				},
+				{
+					Rule:    NewTerraformVariableTypeRequiredRule(),
+					Message: "`any` is used in the type of variable `settings`, declare an exact type instead",
+				},
+			},
+		},
+		{
+			Name: "3. allowed any variables",
+			Config: `
+rule "terraform_variable_type_required" {
+  enabled             = true
+  allow_any_variables = ["tags"]
+}`,
+			Content: `
+variable "anything" {
+  type = any
+}
+
+variable "tags" {
+  type = map(any)
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformVariableTypeRequiredRule(),
+					Message: "`any` is used in the type of variable `anything`, declare an exact type instead",
+				},
+			},
+		},
+		{
+			Name: "4. allow any",
+			Config: `
+rule "terraform_variable_type_required" {
+  enabled   = true
+  allow_any = true
+}`,
+			Content: `
+variable "image_id" {}
+
+variable "anything" {
+  type = any
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformVariableTypeRequiredRule(),
+					Message: "`type` is required for variable `image_id`",
+				},
+			},
+		},
+	}
+	rule := NewTerraformVariableTypeRequiredRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{"config.tf": tc.Content}
+			if tc.Config != "" {
+				files[".tflint.hcl"] = tc.Config
+			}
+			runner := helper.TestRunner(t, files)
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package rules
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/logger"
@@ -43,14 +45,14 @@ func (r *TerraformVersionsFileRule) CheckFile(runner tflint.Runner, file *hcl.Fi
 		return nil
 	}
 	filename := body.Range().Filename
-	if filename != "versions.tf" {
+	if filename != fileName("versions") {
 		return nil
 	}
 	blocks := body.Blocks
 	if len(blocks) != 1 || blocks[0].Type != "terraform" {
 		return runner.EmitIssue(
 			r,
-			"`versions.tf` should have and only have 1 `terraform` block",
+			fmt.Sprintf("`%s` should have and only have 1 `terraform` block", filename),
 			hcl.Range{},
 		)
 	}
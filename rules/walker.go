@@ -0,0 +1,216 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// Walker visits the contents of the HCL files of a module in source order and calls the callbacks declared for them.
+// The files filtered out by the plugin config, the override files and the non-HCL files are skipped.
+type Walker struct {
+	// File is called for every file before its contents
+	File func(ctx *WalkContext) error
+	// Blocks are called for the blocks they match
+	Blocks []BlockVisitor
+	// Attribute is called for every attribute, at any nesting level
+	Attribute func(ctx *WalkContext, attr *hclsyntax.Attribute) error
+	// Expression is called for every expression in the attributes, including the nested ones
+	Expression func(ctx *WalkContext, expr hclsyntax.Expression) error
+	// AfterFile is called for every file after its contents, e.g. to check the blocks collected by the visitors
+	AfterFile func(ctx *WalkContext) error
+	// IncludeOverrideFiles visits the override files as well, e.g. `override.tf` or `main_override.tf`
+	IncludeOverrideFiles bool
+}
+
+// BlockVisitor is called for the blocks of the type whose labels start with the given ones, an empty type or label matches any
+type BlockVisitor struct {
+	Type   string
+	Labels []string
+	// TopLevel only matches the blocks at the top level of the file
+	TopLevel bool
+	Visit    func(ctx *WalkContext, block *hclsyntax.Block) error
+}
+
+// WalkContext is the context of a visited node, it's only valid during the callback
+type WalkContext struct {
+	Runner   tflint.Runner
+	File     *hcl.File
+	Filename string
+	// Parents are the blocks enclosing the node, outermost first
+	Parents []*hclsyntax.Block
+	// Attribute is the attribute enclosing the visited expression
+	Attribute *hclsyntax.Attribute
+	tokens    *hclsyntax.Tokens
+}
+
+// Parent returns the innermost block enclosing the node, or nil at the top level
+func (ctx *WalkContext) Parent() *hclsyntax.Block {
+	if len(ctx.Parents) == 0 {
+		return nil
+	}
+	return ctx.Parents[len(ctx.Parents)-1]
+}
+
+// TopLevelBlock returns the top-level block enclosing the node, or nil at the top level
+func (ctx *WalkContext) TopLevelBlock() *hclsyntax.Block {
+	if len(ctx.Parents) == 0 {
+		return nil
+	}
+	return ctx.Parents[0]
+}
+
+// Tokens returns the tokens of the file, the file is only lexed once
+func (ctx *WalkContext) Tokens() (hclsyntax.Tokens, error) {
+	if *ctx.tokens == nil {
+		tokens, diags := hclsyntax.LexConfig(ctx.File.Bytes, ctx.Filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		*ctx.tokens = tokens
+	}
+	return *ctx.tokens, nil
+}
+
+// Walk visits the files of the module in the order of their names
+func (w *Walker) Walk(runner tflint.Runner) error {
+	files, err := lintedFiles(runner)
+	if err != nil {
+		return err
+	}
+	var filenames []string
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		if subErr := w.WalkFile(runner, files[filename]); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+// WalkFile visits the file
+func (w *Walker) WalkFile(runner tflint.Runner, file *hcl.File) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		logger.Debug("skip walking file since it's not hcl file")
+		return nil
+	}
+	filename := body.Range().Filename
+	if !w.IncludeOverrideFiles && isOverrideTfFile(filename) {
+		logger.Debug(fmt.Sprintf("skip walking %s since it's override file", filename))
+		return nil
+	}
+	ctx := &WalkContext{Runner: runner, File: file, Filename: filename, tokens: new(hclsyntax.Tokens)}
+	var err error
+	if w.File != nil {
+		if subErr := w.File(ctx); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	if subErr := w.walkBody(ctx, body); subErr != nil {
+		err = multierror.Append(err, subErr)
+	}
+	if w.AfterFile != nil {
+		if subErr := w.AfterFile(ctx); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+func (w *Walker) walkBody(ctx *WalkContext, body *hclsyntax.Body) error {
+	var nodes []hclsyntax.Node
+	for _, attr := range body.Attributes {
+		nodes = append(nodes, attr)
+	}
+	for _, block := range body.Blocks {
+		nodes = append(nodes, block)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Range().Start.Byte < nodes[j].Range().Start.Byte
+	})
+	var err error
+	for _, node := range nodes {
+		var subErr error
+		switch n := node.(type) {
+		case *hclsyntax.Attribute:
+			subErr = w.walkAttribute(ctx, n)
+		case *hclsyntax.Block:
+			subErr = w.walkBlock(ctx, n)
+		}
+		if subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	return err
+}
+
+func (w *Walker) walkAttribute(ctx *WalkContext, attr *hclsyntax.Attribute) error {
+	var err error
+	if w.Attribute != nil {
+		if subErr := w.Attribute(ctx, attr); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	if w.Expression == nil {
+		return err
+	}
+	exprCtx := *ctx
+	exprCtx.Attribute = attr
+	_ = hclsyntax.VisitAll(attr.Expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(hclsyntax.Expression)
+		if !ok {
+			return nil
+		}
+		if subErr := w.Expression(&exprCtx, expr); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+		return nil
+	})
+	return err
+}
+
+func (w *Walker) walkBlock(ctx *WalkContext, block *hclsyntax.Block) error {
+	var err error
+	for _, visitor := range w.Blocks {
+		if !visitor.matches(ctx, block) {
+			continue
+		}
+		if subErr := visitor.Visit(ctx, block); subErr != nil {
+			err = multierror.Append(err, subErr)
+		}
+	}
+	nestedCtx := *ctx
+	nestedCtx.Parents = append(slices.Clip(ctx.Parents), block)
+	if subErr := w.walkBody(&nestedCtx, block.Body); subErr != nil {
+		err = multierror.Append(err, subErr)
+	}
+	return err
+}
+
+func (v BlockVisitor) matches(ctx *WalkContext, block *hclsyntax.Block) bool {
+	if v.TopLevel && len(ctx.Parents) > 0 {
+		return false
+	}
+	if v.Type != "" && v.Type != block.Type {
+		return false
+	}
+	if len(v.Labels) > len(block.Labels) {
+		return false
+	}
+	for i, label := range v.Labels {
+		if label != "" && label != block.Labels[i] {
+			return false
+		}
+	}
+	return true
+}
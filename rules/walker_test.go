@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_Walker(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"main.tf": `
+resource "azurerm_resource_group" "example" {
+  name = "example"
+
+  lifecycle {
+    ignore_changes = [tags]
+  }
+
+  location = var.location
+}
+
+resource "azurerm_subnet" "example" {
+  name = "example"
+}`,
+		"main_override.tf": `
+resource "azurerm_resource_group" "example" {
+  location = "westus"
+}`,
+		"variables.tf": `
+# the region
+variable "location" {}`,
+	})
+	var visited []string
+	walker := &Walker{
+		File: func(ctx *WalkContext) error {
+			tokens, err := ctx.Tokens()
+			if err != nil {
+				return err
+			}
+			comments := 0
+			for _, token := range tokens {
+				if token.Type == hclsyntax.TokenComment {
+					comments++
+				}
+			}
+			visited = append(visited, fmt.Sprintf("file %s with %d comments", ctx.Filename, comments))
+			return nil
+		},
+		Blocks: []BlockVisitor{
+			{
+				Type:     "resource",
+				Labels:   []string{"azurerm_resource_group"},
+				TopLevel: true,
+				Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+					visited = append(visited, "resource "+blockAddress(block))
+					return nil
+				},
+			},
+			{
+				Type: "lifecycle",
+				Visit: func(ctx *WalkContext, block *hclsyntax.Block) error {
+					visited = append(visited, "lifecycle of "+blockAddress(ctx.Parent()))
+					return nil
+				},
+			},
+		},
+		Attribute: func(ctx *WalkContext, attr *hclsyntax.Attribute) error {
+			var parents []string
+			for _, parent := range ctx.Parents {
+				parents = append(parents, parent.Type)
+			}
+			visited = append(visited, fmt.Sprintf("attribute %s in %s", attr.Name, strings.Join(parents, ".")))
+			return nil
+		},
+		Expression: func(ctx *WalkContext, expr hclsyntax.Expression) error {
+			if traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr); ok {
+				visited = append(visited, fmt.Sprintf("reference %s in %s", traversal.Traversal.RootName(), ctx.Attribute.Name))
+			}
+			return nil
+		},
+		AfterFile: func(ctx *WalkContext) error {
+			visited = append(visited, "end of file "+ctx.Filename)
+			return nil
+		},
+	}
+	require.NoError(t, walker.Walk(runner))
+	assert.Equal(t, []string{
+		"file main.tf with 0 comments",
+		"resource azurerm_resource_group.example",
+		"attribute name in resource",
+		"lifecycle of azurerm_resource_group.example",
+		"attribute ignore_changes in resource.lifecycle",
+		"reference tags in ignore_changes",
+		"attribute location in resource",
+		"reference var in location",
+		"attribute name in resource",
+		"end of file main.tf",
+		"file variables.tf with 1 comments",
+		"end of file variables.tf",
+	}, visited)
+}